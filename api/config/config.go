@@ -4,11 +4,14 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"strconv"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
+	// AppEnv es "development" en local; cualquier otro valor se trata como producción
+	AppEnv       string
 	DBUrl        string
 	SupabaseURL  string
 	SupabaseKey  string
@@ -18,6 +21,14 @@ type Config struct {
 	SMTPPort     string
 	SMTPEmail    string
 	SMTPPassword string
+
+	CIE10CatalogPath string
+	PublicAPIURL     string
+	FrontendURL      string
+
+	// Escalación de incidentes sin reconocer; 0 minutos la desactiva
+	IncidentEscalationMinutes     int
+	IncidentEscalationMinSeverity string
 }
 
 func LoadConfig() *Config {
@@ -35,6 +46,7 @@ func LoadConfig() *Config {
 	)
 
 	cfg := &Config{
+		AppEnv:       getEnv("APP_ENV", "production"),
 		DBUrl:        dsn,
 		SupabaseURL:  getEnv("SUPABASE_URL", ""),
 		SupabaseKey:  getEnv("SUPABASE_SERVICE_ROLE_KEY", ""),
@@ -44,6 +56,13 @@ func LoadConfig() *Config {
 		SMTPPort:     getEnv("SMTP_PORT", "587"),
 		SMTPEmail:    getEnv("SMTP_EMAIL", ""),
 		SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+
+		CIE10CatalogPath: getEnv("CIE10_CATALOG_PATH", ""),
+		PublicAPIURL:     getEnv("PUBLIC_API_URL", ""),
+		FrontendURL:      getEnv("FRONTEND_URL", ""),
+
+		IncidentEscalationMinutes:     getEnvInt("INCIDENT_ESCALATION_MINUTES", 30),
+		IncidentEscalationMinSeverity: getEnv("INCIDENT_ESCALATION_MIN_SEVERITY", "HIGH"),
 	}
 
 	if cfg.JwtSecret == "" {
@@ -53,9 +72,26 @@ func LoadConfig() *Config {
 	return cfg
 }
 
+func (c *Config) IsDevelopment() bool {
+	return c.AppEnv == "development"
+}
+
 func getEnv(key, fallback string) string {
 	if value, exists := os.LookupEnv(key); exists {
 		return value
 	}
 	return fallback
 }
+
+func getEnvInt(key string, fallback int) int {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return fallback
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		slog.Warn("Invalid integer env var, using default", "key", key, "value", value)
+		return fallback
+	}
+	return n
+}
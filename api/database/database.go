@@ -4,8 +4,11 @@ import (
 	"log/slog"
 	"time"
 
+	"bitacora-medica-backend/api/domains"
+
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/gorm/logger"
 )
 
@@ -39,6 +42,114 @@ func Connect(dbUrl string) {
 	slog.Info("Database connection established successfully with pool limits")
 }
 
+// Migrate crea las tablas de los módulos nuevos y agrega a las tablas base que
+// venían de Supabase (patients, collaborations, sessions) las columnas e índices
+// que esos módulos necesitan. AutoMigrate solo agrega: nunca borra ni cambia el
+// tipo de una columna existente. users sigue administrándose en Supabase.
+func Migrate() {
+	err := DB.AutoMigrate(
+		&appliedMigration{},
+		&domains.Patient{},
+		&domains.PatientStatusHistory{},
+		&domains.PatientDiagnosis{},
+		&domains.PatientMedication{},
+		&domains.SessionMedicationEvent{},
+		&domains.PatientAlert{},
+		&domains.TherapeuticGoal{},
+		&domains.GoalProgress{},
+		&domains.Organization{},
+		&domains.OrganizationMember{},
+		&domains.Tag{},
+		&domains.PatientTag{},
+		&domains.SavedCohort{},
+		&domains.PatientContact{},
+		&domains.Collaboration{},
+		&domains.PatientProfileChange{},
+		&domains.GroupSession{},
+		&domains.Session{},
+		&domains.Appointment{},
+		&domains.CalendarFeedToken{},
+		&domains.AttendanceRecord{},
+		&domains.VitalThreshold{},
+		&domains.SessionTemplate{},
+		&domains.SessionDraft{},
+		&domains.SyncReceipt{},
+		&domains.Incident{},
+		&domains.IncidentAction{},
+		&domains.IncidentEscalation{},
+		&domains.Supervision{},
+		&domains.Comment{},
+		&domains.CommentRevision{},
+		&domains.Mention{},
+	)
+	if err != nil {
+		slog.Error("Failed to run migrations", "error", err)
+		panic("Failed to run migrations")
+	}
+
+	createSessionSearchIndex()
+	createPatientRUTIndex()
+	runOnce("backfill_incidents", backfillIncidents)
+
+	slog.Info("Database migrations applied")
+}
+
+// createSessionSearchIndex crea el índice GIN de la búsqueda de texto completo de sesiones.
+func createSessionSearchIndex() {
+	err := DB.Exec("CREATE INDEX IF NOT EXISTS idx_sessions_search ON sessions USING GIN (" + domains.SessionSearchVectorSQL + ")").Error
+	if err != nil {
+		slog.Error("Failed to create session search index", "error", err)
+	}
+}
+
+// createPatientRUTIndex impide registrar dos pacientes vigentes con el mismo RUT normalizado.
+// Si ya hay duplicados el índice no se crea: hay que resolverlos y reiniciar.
+func createPatientRUTIndex() {
+	err := DB.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_patients_rut ON patients ((" + domains.PatientRUTSQL + ")) " +
+		"WHERE deleted_at IS NULL AND " + domains.PatientRUTSQL + " <> ''").Error
+	if err != nil {
+		slog.Error("Failed to create patient RUT unique index", "error", err)
+	}
+}
+
+// appliedMigration registra las migraciones de datos que deben correr una sola vez.
+type appliedMigration struct {
+	ID        string    `gorm:"type:varchar(100);primaryKey"`
+	AppliedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (appliedMigration) TableName() string { return "app_migrations" }
+
+// runOnce ejecuta la migración de datos id si aún no se aplicó. El registro y la
+// migración van en la misma transacción: si falla se reintenta en el próximo
+// arranque, y dos instancias que arrancan a la vez no la corren dos veces.
+func runOnce(id string, migrate func(tx *gorm.DB) error) {
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&appliedMigration{ID: id})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		slog.Info("Applying data migration", "id", id)
+		return migrate(tx)
+	})
+	if err != nil {
+		slog.Error("Failed to apply data migration", "id", id, "error", err)
+	}
+}
+
+// backfillIncidents crea el incidente de las sesiones que lo reportaron antes de
+// existir el seguimiento. El equipo ya fue avisado por correo en su momento, por
+// lo que quedan como ACKNOWLEDGED (sin responsable) y no entran en la escalación.
+func backfillIncidents(tx *gorm.DB) error {
+	return tx.Exec(`
+		INSERT INTO incidents (patient_id, session_id, reported_by_id, severity, category, details, photo, status, acknowledged_at, created_at, updated_at)
+		SELECT s.patient_id, s.id, s.professional_id, ?, ?, coalesce(s.incident_details, ''), s.incident_photo, ?, s.created_at, s.created_at, now()
+		FROM sessions s
+		WHERE s.has_incident AND s.deleted_at IS NULL
+		  AND NOT EXISTS (SELECT 1 FROM incidents i WHERE i.session_id = s.id)`,
+		domains.SeverityModerate, domains.IncidentOther, domains.IncidentAcknowledged).Error
+}
+
 func GetDB() *gorm.DB {
 	return DB
 }
@@ -0,0 +1,87 @@
+package domains
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AlertType string
+
+const (
+	AlertAllergy          AlertType = "ALLERGY"
+	AlertContraindication AlertType = "CONTRAINDICATION"
+	AlertSeizureRisk      AlertType = "SEIZURE_RISK"
+	AlertBehavioral       AlertType = "BEHAVIORAL"
+	AlertFallRisk         AlertType = "FALL_RISK"
+	AlertOther            AlertType = "OTHER"
+)
+
+type Severity string
+
+const (
+	SeverityLow      Severity = "LOW"
+	SeverityModerate Severity = "MODERATE"
+	SeverityHigh     Severity = "HIGH"
+	SeverityCritical Severity = "CRITICAL"
+)
+
+// Rank devuelve la gravedad como número creciente (LOW=1 ... CRITICAL=4); 0 si no es válida.
+func (s Severity) Rank() int {
+	switch s {
+	case SeverityLow:
+		return 1
+	case SeverityModerate:
+		return 2
+	case SeverityHigh:
+		return 3
+	case SeverityCritical:
+		return 4
+	}
+	return 0
+}
+
+// SeveritiesAtLeast devuelve las gravedades iguales o mayores que min.
+func SeveritiesAtLeast(min Severity) []Severity {
+	var result []Severity
+	for _, s := range []Severity{SeverityLow, SeverityModerate, SeverityHigh, SeverityCritical} {
+		if s.Rank() >= min.Rank() {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// SeverityOrderSQL ordena por gravedad descendente en consultas SQL.
+const SeverityOrderSQL = "CASE severity WHEN 'CRITICAL' THEN 0 WHEN 'HIGH' THEN 1 WHEN 'MODERATE' THEN 2 ELSE 3 END"
+
+type PatientAlert struct {
+	ID          uuid.UUID      `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	PatientID   uuid.UUID      `gorm:"type:uuid;not null;index"`
+	Type        AlertType      `gorm:"type:varchar(30);not null"`
+	Severity    Severity       `gorm:"type:varchar(20);not null"`
+	Title       string         `gorm:"type:varchar(255);not null"`
+	Description string         `gorm:"type:text"`
+	IsActive    bool           `gorm:"not null;default:true;index"`
+	CreatedByID uuid.UUID      `gorm:"type:uuid;not null"`
+	CreatedAt   time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+	CreatedBy   User           `gorm:"foreignKey:CreatedByID"`
+}
+
+type CreateAlertInput struct {
+	Type        string `json:"type" binding:"required,oneof=ALLERGY CONTRAINDICATION SEIZURE_RISK BEHAVIORAL FALL_RISK OTHER"`
+	Severity    string `json:"severity" binding:"required,oneof=LOW MODERATE HIGH CRITICAL"`
+	Title       string `json:"title" binding:"required"`
+	Description string `json:"description"`
+}
+
+type UpdateAlertInput struct {
+	Type        string `json:"type" binding:"omitempty,oneof=ALLERGY CONTRAINDICATION SEIZURE_RISK BEHAVIORAL FALL_RISK OTHER"`
+	Severity    string `json:"severity" binding:"omitempty,oneof=LOW MODERATE HIGH CRITICAL"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	IsActive    *bool  `json:"is_active"`
+}
@@ -0,0 +1,104 @@
+package domains
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AppointmentModality string
+
+const (
+	ModalityInPerson   AppointmentModality = "IN_PERSON"
+	ModalityHomeVisit  AppointmentModality = "HOME_VISIT"
+	ModalityTelehealth AppointmentModality = "TELEHEALTH"
+)
+
+type AppointmentStatus string
+
+const (
+	AppointmentScheduled AppointmentStatus = "SCHEDULED"
+	AppointmentConfirmed AppointmentStatus = "CONFIRMED"
+	AppointmentCompleted AppointmentStatus = "COMPLETED"
+	AppointmentCancelled AppointmentStatus = "CANCELLED"
+	AppointmentNoShow    AppointmentStatus = "NO_SHOW"
+)
+
+// IsOpen indica si la cita sigue pendiente (ocupa la agenda y se puede completar o cancelar).
+func (s AppointmentStatus) IsOpen() bool {
+	return s == AppointmentScheduled || s == AppointmentConfirmed
+}
+
+// MaxRecurrenceOccurrences limita cuántas citas genera una regla de recurrencia.
+const MaxRecurrenceOccurrences = 52
+
+type Appointment struct {
+	ID             uuid.UUID           `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	PatientID      uuid.UUID           `gorm:"type:uuid;not null;index"`
+	ProfessionalID uuid.UUID           `gorm:"type:uuid;not null;index:idx_appointment_professional_time"`
+	StartsAt       time.Time           `gorm:"not null;index:idx_appointment_professional_time"`
+	EndsAt         time.Time           `gorm:"not null"`
+	Modality       AppointmentModality `gorm:"type:varchar(20);default:'IN_PERSON';not null"`
+	Location       string              `gorm:"type:text"` // Dirección, box o link de videollamada
+	Notes          string              `gorm:"type:text"`
+	Status         AppointmentStatus   `gorm:"type:varchar(20);default:'SCHEDULED';not null;index"`
+	StatusReason   string              `gorm:"type:text"`
+	SeriesID       *uuid.UUID          `gorm:"type:uuid;index"` // Comparten SeriesID las citas de una misma recurrencia
+	RecurrenceRule string              `gorm:"type:varchar(100)"`
+	SessionID      *uuid.UUID          `gorm:"type:uuid"` // Sesión registrada al completar la cita
+	CreatedByID    uuid.UUID           `gorm:"type:uuid;not null"`
+	CreatedAt      time.Time           `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time           `gorm:"autoUpdateTime"`
+	DeletedAt      gorm.DeletedAt      `gorm:"index"`
+	Patient        Patient             `gorm:"foreignKey:PatientID"`
+	Professional   User                `gorm:"foreignKey:ProfessionalID"`
+}
+
+type RecurrenceInput struct {
+	Frequency string `json:"frequency" binding:"required,oneof=WEEKLY"`
+	Interval  int    `json:"interval" binding:"omitempty,min=1,max=4"` // Cada cuántas semanas (default 1)
+	Count     int    `json:"count" binding:"omitempty,min=1,max=52"`
+	Until     string `json:"until"` // YYYY-MM-DD, alternativa a count
+}
+
+type CreateAppointmentInput struct {
+	PatientID      string           `json:"patient_id" binding:"required"`
+	ProfessionalID string           `json:"professional_id"` // Por defecto, el usuario actual
+	StartsAt       time.Time        `json:"starts_at" binding:"required"`
+	EndsAt         time.Time        `json:"ends_at" binding:"required"`
+	Modality       string           `json:"modality" binding:"omitempty,oneof=IN_PERSON HOME_VISIT TELEHEALTH"`
+	Location       string           `json:"location"`
+	Notes          string           `json:"notes"`
+	Recurrence     *RecurrenceInput `json:"recurrence"`
+}
+
+type UpdateAppointmentInput struct {
+	StartsAt *time.Time `json:"starts_at"`
+	EndsAt   *time.Time `json:"ends_at"`
+	Modality string     `json:"modality" binding:"omitempty,oneof=IN_PERSON HOME_VISIT TELEHEALTH"`
+	Location string     `json:"location"`
+	Notes    string     `json:"notes"`
+}
+
+type ChangeAppointmentStatusInput struct {
+	Status        string `json:"status" binding:"required,oneof=SCHEDULED CONFIRMED CANCELLED NO_SHOW"`
+	Reason        string `json:"reason"`
+	CancelledBy   string `json:"cancelled_by" binding:"omitempty,oneof=FAMILY PROFESSIONAL"` // Requerido al cancelar, para las estadísticas de asistencia
+	ApplyToSeries bool   `json:"apply_to_series"`                                            // Aplica a esta cita y las siguientes abiertas de la serie
+}
+
+// AttendanceStatus traduce el nuevo estado de la cita al registro de asistencia.
+// Devuelve "" para los estados abiertos, que no generan registro.
+func (in ChangeAppointmentStatusInput) AttendanceStatus() AttendanceStatus {
+	switch AppointmentStatus(in.Status) {
+	case AppointmentCancelled:
+		if in.CancelledBy == "FAMILY" {
+			return AttendanceCancelledByFamily
+		}
+		return AttendanceCancelledByProfessional
+	case AppointmentNoShow:
+		return AttendanceNoShow
+	}
+	return ""
+}
@@ -0,0 +1,64 @@
+package domains
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AttendanceStatus string
+
+const (
+	AttendanceAttended                AttendanceStatus = "ATTENDED"
+	AttendanceCancelledByFamily       AttendanceStatus = "CANCELLED_BY_FAMILY"
+	AttendanceCancelledByProfessional AttendanceStatus = "CANCELLED_BY_PROFESSIONAL"
+	AttendanceNoShow                  AttendanceStatus = "NO_SHOW"
+)
+
+// AttendanceRecord registra si una atención programada ocurrió o no.
+// Se crea automáticamente al registrar sesiones y al cancelar o marcar ausencia en
+// una cita; también se puede registrar a mano (ej: cancelación telefónica sin cita).
+type AttendanceRecord struct {
+	ID             uuid.UUID        `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	PatientID      uuid.UUID        `gorm:"type:uuid;not null;index"`
+	ProfessionalID uuid.UUID        `gorm:"type:uuid;not null;index"`
+	AppointmentID  *uuid.UUID       `gorm:"type:uuid;index"`
+	SessionID      *uuid.UUID       `gorm:"type:uuid"`
+	Status         AttendanceStatus `gorm:"type:varchar(30);not null;index"`
+	Reason         string           `gorm:"type:text"`
+	OccurredAt     time.Time        `gorm:"not null;index"` // Fecha de la atención programada
+	RecordedByID   uuid.UUID        `gorm:"type:uuid;not null"`
+	CreatedAt      time.Time        `gorm:"autoCreateTime"`
+	Professional   User             `gorm:"foreignKey:ProfessionalID"`
+	RecordedBy     User             `gorm:"foreignKey:RecordedByID"`
+}
+
+type CreateAttendanceInput struct {
+	Status         string     `json:"status" binding:"required,oneof=ATTENDED CANCELLED_BY_FAMILY CANCELLED_BY_PROFESSIONAL NO_SHOW"`
+	Reason         string     `json:"reason"`
+	OccurredAt     *time.Time `json:"occurred_at"`     // Por defecto, ahora
+	ProfessionalID string     `json:"professional_id"` // Por defecto, el usuario actual
+}
+
+// AttendanceStats resume los registros de asistencia. Las tasas se calculan sobre el total.
+type AttendanceStats struct {
+	Total                   int64   `json:"total"`
+	Attended                int64   `json:"attended"`
+	CancelledByFamily       int64   `json:"cancelled_by_family"`
+	CancelledByProfessional int64   `json:"cancelled_by_professional"`
+	NoShow                  int64   `json:"no_show"`
+	AttendanceRate          float64 `json:"attendance_rate"`
+	NoShowRate              float64 `json:"no_show_rate"`
+}
+
+type PatientAttendanceStats struct {
+	PatientID   uuid.UUID       `json:"patient_id"`
+	PatientName string          `json:"patient_name"`
+	Stats       AttendanceStats `json:"stats"`
+}
+
+type ProfessionalAttendanceStats struct {
+	ProfessionalID   uuid.UUID       `json:"professional_id"`
+	ProfessionalName string          `json:"professional_name"`
+	Stats            AttendanceStats `json:"stats"`
+}
@@ -0,0 +1,22 @@
+package domains
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CalendarFeedToken es el token secreto del feed iCalendar de un usuario.
+// Solo se guarda el hash SHA-256; el token en claro se muestra una única vez.
+type CalendarFeedToken struct {
+	ID            uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	UserID        uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	TokenHash     string    `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"`
+	ShowFullNames bool      `gorm:"not null;default:false"` // Por defecto solo iniciales del paciente
+	CreatedAt     time.Time `gorm:"autoCreateTime"`
+	LastUsedAt    *time.Time
+}
+
+type GenerateCalendarTokenInput struct {
+	ShowFullNames bool `json:"show_full_names"`
+}
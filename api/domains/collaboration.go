@@ -21,9 +21,11 @@ type Collaboration struct {
 	ProfessionalID uuid.UUID    `gorm:"type:uuid;not null"`
 	Status         CollabStatus `gorm:"type:varchar(20);default:'PENDING';not null"`
 	InvitedAt      time.Time    `gorm:"autoCreateTime"`
-	UpdatedAt      time.Time    `gorm:"autoUpdateTime"`
-	Professional   User         `gorm:"foreignKey:ProfessionalID"`
-	Patient        Patient      `gorm:"foreignKey:PatientID"`
+	AcceptedAt     *time.Time
+	RevokedAt      *time.Time
+	UpdatedAt      time.Time `gorm:"autoUpdateTime"`
+	Professional   User      `gorm:"foreignKey:ProfessionalID"`
+	Patient        Patient   `gorm:"foreignKey:PatientID"`
 }
 
 type InviteInput struct {
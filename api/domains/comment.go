@@ -0,0 +1,42 @@
+package domains
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Comment es un mensaje del equipo tratante sobre un paciente o una de sus
+// sesiones. Los hilos tienen un nivel: las respuestas apuntan al comentario raíz.
+type Comment struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	PatientID uuid.UUID  `gorm:"type:uuid;not null;index"`
+	SessionID *uuid.UUID `gorm:"type:uuid;index"` // nil: comentario sobre el paciente
+	ParentID  *uuid.UUID `gorm:"type:uuid;index"`
+	AuthorID  uuid.UUID  `gorm:"type:uuid;not null"`
+	Body      string     `gorm:"type:text;not null"`
+	EditedAt  *time.Time
+	CreatedAt time.Time      `gorm:"autoCreateTime;index"`
+	UpdatedAt time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+	Author    User           `gorm:"foreignKey:AuthorID"`
+	Replies   []Comment      `gorm:"foreignKey:ParentID"`
+}
+
+// CommentRevision guarda el texto anterior de un comentario cada vez que se edita.
+type CommentRevision struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	CommentID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Body      string    `gorm:"type:text;not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"` // Momento en que se reemplazó este texto
+}
+
+type CreateCommentInput struct {
+	Body     string `json:"body" binding:"required"`
+	ParentID string `json:"parent_id"` // Responde a un comentario del mismo hilo
+}
+
+type UpdateCommentInput struct {
+	Body string `json:"body" binding:"required"`
+}
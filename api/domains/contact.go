@@ -0,0 +1,49 @@
+package domains
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// PatientContact es un adulto responsable o contacto de emergencia del paciente.
+// ContactOrder define la prioridad de llamado (1 = primero).
+type PatientContact struct {
+	ID                  uuid.UUID      `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	PatientID           uuid.UUID      `gorm:"type:uuid;not null;index"`
+	Name                string         `gorm:"type:varchar(255);not null"`
+	Relationship        string         `gorm:"type:varchar(100);not null"`
+	Phones              pq.StringArray `gorm:"type:text[]"`
+	Email               string         `gorm:"type:varchar(255)"`
+	IsLegalGuardian     bool           `gorm:"not null;default:false"`
+	HasConsentAuthority bool           `gorm:"not null;default:false"`
+	ContactOrder        int            `gorm:"not null;default:1"`
+	NotifyIncidents     bool           `gorm:"not null;default:false"` // Recibe por email los incidentes reportados
+	CreatedAt           time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt           time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt           gorm.DeletedAt `gorm:"index"`
+}
+
+type CreateContactInput struct {
+	Name                string   `json:"name" binding:"required"`
+	Relationship        string   `json:"relationship" binding:"required"`
+	Phones              []string `json:"phones" binding:"required,min=1"`
+	Email               string   `json:"email" binding:"omitempty,email"`
+	IsLegalGuardian     bool     `json:"is_legal_guardian"`
+	HasConsentAuthority bool     `json:"has_consent_authority"`
+	ContactOrder        int      `json:"contact_order" binding:"omitempty,min=1"`
+	NotifyIncidents     bool     `json:"notify_incidents"`
+}
+
+type UpdateContactInput struct {
+	Name                string   `json:"name"`
+	Relationship        string   `json:"relationship"`
+	Phones              []string `json:"phones"`
+	Email               string   `json:"email" binding:"omitempty,email"`
+	IsLegalGuardian     *bool    `json:"is_legal_guardian"`
+	HasConsentAuthority *bool    `json:"has_consent_authority"`
+	ContactOrder        int      `json:"contact_order" binding:"omitempty,min=1"`
+	NotifyIncidents     *bool    `json:"notify_incidents"`
+}
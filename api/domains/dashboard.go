@@ -1,10 +1,11 @@
 package domains
 
 type AdminDashboardStats struct {
-	TotalUsers     int64 `json:"total_users"`
-	PendingUsers   int64 `json:"pending_users"`
-	ActivePatients int64 `json:"active_patients"`
-	TotalSessions  int64 `json:"total_sessions"`
+	TotalUsers       int64            `json:"total_users"`
+	PendingUsers     int64            `json:"pending_users"`
+	ActivePatients   int64            `json:"active_patients"`
+	TotalSessions    int64            `json:"total_sessions"`
+	PatientsByStatus map[string]int64 `json:"patients_by_status"`
 }
 
 type UserGrowthStats struct {
@@ -13,12 +14,21 @@ type UserGrowthStats struct {
 }
 
 type ProfessionalDashboardStats struct {
-	ActivePatients    int64 `json:"active_patients"`
-	MonthlySessions   int64 `json:"monthly_sessions"`
-	ReportedIncidents int64 `json:"reported_incidents"`
+	ActivePatients    int64            `json:"active_patients"`
+	MonthlySessions   int64            `json:"monthly_sessions"`
+	ReportedIncidents int64            `json:"reported_incidents"`
+	IncidentsByStatus map[string]int64 `json:"incidents_by_status"`
+	PatientsByStatus  map[string]int64 `json:"patients_by_status"`
+	Attendance        AttendanceStats  `json:"attendance"` // Últimos 90 días
 }
 
 type ActivityStats struct {
 	Day   string `json:"name"`
 	Count int64  `json:"sesiones"`
 }
+
+type DiagnosisGroupStats struct {
+	GroupCode string `json:"group_code"`
+	GroupName string `json:"group_name"`
+	Patients  int64  `json:"patients"`
+}
@@ -0,0 +1,64 @@
+package domains
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type DiagnosisType string
+
+const (
+	DiagnosisPrimary   DiagnosisType = "PRIMARY"
+	DiagnosisSecondary DiagnosisType = "SECONDARY"
+)
+
+type DiagnosisStatus string
+
+const (
+	DiagnosisActive   DiagnosisStatus = "ACTIVE"
+	DiagnosisResolved DiagnosisStatus = "RESOLVED"
+	DiagnosisRuledOut DiagnosisStatus = "RULED_OUT"
+)
+
+// DiagnosisCatalogEntry es una fila del catálogo CIE-10 incluido en el binario.
+type DiagnosisCatalogEntry struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+	GroupCode   string `json:"group_code"`
+	GroupName   string `json:"group_name"`
+}
+
+type PatientDiagnosis struct {
+	ID            uuid.UUID       `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	PatientID     uuid.UUID       `gorm:"type:uuid;not null;index"`
+	Code          string          `gorm:"type:varchar(10);not null;index"`
+	Description   string          `gorm:"type:text;not null"`
+	GroupCode     string          `gorm:"type:varchar(10);index"`
+	GroupName     string          `gorm:"type:text"`
+	Type          DiagnosisType   `gorm:"type:varchar(20);default:'SECONDARY';not null"`
+	Status        DiagnosisStatus `gorm:"type:varchar(20);default:'ACTIVE';not null"`
+	OnsetDate     *time.Time      `gorm:"type:date"`
+	DiagnosedByID uuid.UUID       `gorm:"type:uuid;not null"`
+	Notes         string          `gorm:"type:text"`
+	CreatedAt     time.Time       `gorm:"autoCreateTime"`
+	UpdatedAt     time.Time       `gorm:"autoUpdateTime"`
+	DeletedAt     gorm.DeletedAt  `gorm:"index"`
+	DiagnosedBy   User            `gorm:"foreignKey:DiagnosedByID"`
+}
+
+type CreateDiagnosisInput struct {
+	Code      string `json:"code" binding:"required"`
+	Type      string `json:"type" binding:"omitempty,oneof=PRIMARY SECONDARY"`
+	Status    string `json:"status" binding:"omitempty,oneof=ACTIVE RESOLVED RULED_OUT"`
+	OnsetDate string `json:"onset_date"` // YYYY-MM-DD
+	Notes     string `json:"notes"`
+}
+
+type UpdateDiagnosisInput struct {
+	Type      string `json:"type" binding:"omitempty,oneof=PRIMARY SECONDARY"`
+	Status    string `json:"status" binding:"omitempty,oneof=ACTIVE RESOLVED RULED_OUT"`
+	OnsetDate string `json:"onset_date"` // YYYY-MM-DD
+	Notes     string `json:"notes"`
+}
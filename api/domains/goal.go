@@ -0,0 +1,79 @@
+package domains
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type GoalStatus string
+
+const (
+	GoalActive            GoalStatus = "ACTIVE"
+	GoalAchieved          GoalStatus = "ACHIEVED"
+	GoalPartiallyAchieved GoalStatus = "PARTIALLY_ACHIEVED"
+	GoalNotAchieved       GoalStatus = "NOT_ACHIEVED"
+	GoalCancelled         GoalStatus = "CANCELLED"
+)
+
+type TherapeuticGoal struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	PatientID   uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Title       string     `gorm:"type:varchar(255);not null"`
+	Description string     `gorm:"type:text"`
+	Baseline    string     `gorm:"type:text"`
+	Target      string     `gorm:"type:text;not null"`
+	OwnerID     uuid.UUID  `gorm:"type:uuid;not null;index"`
+	DueDate     *time.Time `gorm:"type:date"`
+	Status      GoalStatus `gorm:"type:varchar(30);default:'ACTIVE';not null"`
+	ClosedAt    *time.Time
+	CreatedAt   time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+	Owner       User           `gorm:"foreignKey:OwnerID"`
+}
+
+// GoalProgress es un registro de avance sobre un objetivo, normalmente hecho en una sesión.
+// Score va de 0 (línea base) a 100 (objetivo logrado).
+type GoalProgress struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	GoalID       uuid.UUID  `gorm:"type:uuid;not null;index"`
+	PatientID    uuid.UUID  `gorm:"type:uuid;not null;index"`
+	SessionID    *uuid.UUID `gorm:"type:uuid;index"`
+	RecordedByID uuid.UUID  `gorm:"type:uuid;not null"`
+	Score        *int
+	Note         string    `gorm:"type:text"`
+	CreatedAt    time.Time `gorm:"autoCreateTime;index"`
+	RecordedBy   User      `gorm:"foreignKey:RecordedByID"`
+}
+
+type CreateGoalInput struct {
+	Title       string `json:"title" binding:"required"`
+	Description string `json:"description"`
+	Baseline    string `json:"baseline"`
+	Target      string `json:"target" binding:"required"`
+	OwnerID     string `json:"owner_id"` // por defecto quien crea el objetivo
+	DueDate     string `json:"due_date"` // YYYY-MM-DD
+}
+
+type UpdateGoalInput struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Baseline    string `json:"baseline"`
+	Target      string `json:"target"`
+	OwnerID     string `json:"owner_id"`
+	DueDate     string `json:"due_date"` // YYYY-MM-DD
+	Status      string `json:"status" binding:"omitempty,oneof=ACTIVE ACHIEVED PARTIALLY_ACHIEVED NOT_ACHIEVED CANCELLED"`
+}
+
+type GoalProgressInput struct {
+	GoalID string `json:"goal_id" binding:"required"`
+	Score  *int   `json:"score" binding:"omitempty,min=0,max=100"`
+	Note   string `json:"note"`
+}
+
+type RecordGoalProgressInput struct {
+	Score *int   `json:"score" binding:"omitempty,min=0,max=100"`
+	Note  string `json:"note"`
+}
@@ -0,0 +1,77 @@
+package domains
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GroupSession agrupa las sesiones de una intervención grupal. Cada participante
+// tiene su propia Session (con su desempeño, logros, signos vitales e incidentes)
+// que comparte descripción y plan con el grupo.
+type GroupSession struct {
+	ID               uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	ProfessionalID   uuid.UUID `gorm:"type:uuid;not null;index"`
+	Title            string    `gorm:"type:varchar(150)"`
+	Description      string    `gorm:"type:text;not null"`
+	InterventionPlan string    `gorm:"type:text;not null"`
+	CreatedAt        time.Time `gorm:"index"`
+	UpdatedAt        time.Time
+	DeletedAt        gorm.DeletedAt `gorm:"index"`
+	Sessions         []Session      `gorm:"foreignKey:GroupSessionID"`
+}
+
+// GroupParticipantInput son los campos propios de cada paciente del grupo.
+type GroupParticipantInput struct {
+	PatientID          string                 `json:"patient_id" binding:"required"`
+	Achievements       string                 `json:"achievements"`
+	PatientPerformance string                 `json:"patient_performance"`
+	Vitals             map[string]interface{} `json:"vitals"`
+	Photos             []string               `json:"photos"`
+	HasIncident        bool                   `json:"has_incident"`
+	IncidentDetails    string                 `json:"incident_details"`
+	IncidentPhoto      string                 `json:"incident_photo"`
+	IncidentSeverity   string                 `json:"incident_severity" binding:"omitempty,oneof=LOW MODERATE HIGH CRITICAL"`
+	IncidentCategory   string                 `json:"incident_category" binding:"omitempty,oneof=FALL SEIZURE BEHAVIORAL MEDICATION_ERROR INJURY OTHER"`
+	NextSessionNotes   string                 `json:"next_session_notes"`
+	AppointmentID      string                 `json:"appointment_id"`
+}
+
+type CreateGroupSessionInput struct {
+	Title            string                  `json:"title"`
+	Description      string                  `json:"description" binding:"required"`
+	InterventionPlan string                  `json:"intervention_plan" binding:"required"`
+	NextSessionNotes string                  `json:"next_session_notes"` // Por defecto para los participantes que no traen las suyas
+	Participants     []GroupParticipantInput `json:"participants" binding:"required,min=2,max=30,dive"`
+}
+
+type UpdateGroupSessionInput struct {
+	Title            string `json:"title"`
+	Description      string `json:"description" binding:"required"`
+	InterventionPlan string `json:"intervention_plan" binding:"required"`
+}
+
+// SessionInput arma el input de la sesión individual de un participante.
+func (in CreateGroupSessionInput) SessionInput(p GroupParticipantInput) CreateSessionInput {
+	nextSessionNotes := p.NextSessionNotes
+	if nextSessionNotes == "" {
+		nextSessionNotes = in.NextSessionNotes
+	}
+	return CreateSessionInput{
+		PatientID:          p.PatientID,
+		InterventionPlan:   in.InterventionPlan,
+		Description:        in.Description,
+		Vitals:             p.Vitals,
+		Achievements:       p.Achievements,
+		PatientPerformance: p.PatientPerformance,
+		Photos:             p.Photos,
+		HasIncident:        p.HasIncident,
+		IncidentDetails:    p.IncidentDetails,
+		IncidentPhoto:      p.IncidentPhoto,
+		IncidentSeverity:   p.IncidentSeverity,
+		IncidentCategory:   p.IncidentCategory,
+		NextSessionNotes:   nextSessionNotes,
+		AppointmentID:      p.AppointmentID,
+	}
+}
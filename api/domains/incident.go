@@ -0,0 +1,110 @@
+package domains
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+type IncidentCategory string
+
+const (
+	IncidentFall            IncidentCategory = "FALL"
+	IncidentSeizure         IncidentCategory = "SEIZURE"
+	IncidentBehavioral      IncidentCategory = "BEHAVIORAL"
+	IncidentMedicationError IncidentCategory = "MEDICATION_ERROR"
+	IncidentInjury          IncidentCategory = "INJURY"
+	IncidentOther           IncidentCategory = "OTHER"
+)
+
+type IncidentStatus string
+
+const (
+	IncidentOpen         IncidentStatus = "OPEN"
+	IncidentAcknowledged IncidentStatus = "ACKNOWLEDGED"
+	IncidentUnderReview  IncidentStatus = "UNDER_REVIEW"
+	IncidentClosed       IncidentStatus = "CLOSED"
+)
+
+// IncidentStatuses en el orden del flujo, para los conteos por estado.
+var IncidentStatuses = []IncidentStatus{IncidentOpen, IncidentAcknowledged, IncidentUnderReview, IncidentClosed}
+
+// Incident es un evento adverso con seguimiento. Se crea al registrar una sesión
+// con has_incident; la sesión conserva el resumen (IncidentDetails).
+type Incident struct {
+	ID               uuid.UUID        `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	PatientID        uuid.UUID        `gorm:"type:uuid;not null;index"`
+	SessionID        *uuid.UUID       `gorm:"type:uuid;uniqueIndex"`
+	ReportedByID     uuid.UUID        `gorm:"type:uuid;not null;index"`
+	Severity         Severity         `gorm:"type:varchar(20);default:'MODERATE';not null"`
+	Category         IncidentCategory `gorm:"type:varchar(30);default:'OTHER';not null"`
+	Details          string           `gorm:"type:text;not null"`
+	Photo            string           `gorm:"type:text"`
+	Status           IncidentStatus   `gorm:"type:varchar(20);default:'OPEN';not null;index"`
+	RootCause        string           `gorm:"type:text"`
+	AcknowledgedByID *uuid.UUID       `gorm:"type:uuid"`
+	AcknowledgedAt   *time.Time
+	EscalationLevel  int `gorm:"not null;default:0"` // Último paso de escalación aplicado
+	ClosedAt         *time.Time
+	CreatedAt        time.Time            `gorm:"autoCreateTime;index"`
+	UpdatedAt        time.Time            `gorm:"autoUpdateTime"`
+	DeletedAt        gorm.DeletedAt       `gorm:"index"`
+	ReportedBy       User                 `gorm:"foreignKey:ReportedByID"`
+	AcknowledgedBy   *User                `gorm:"foreignKey:AcknowledgedByID"`
+	Actions          []IncidentAction     `gorm:"foreignKey:IncidentID"`
+	Escalations      []IncidentEscalation `gorm:"foreignKey:IncidentID"`
+}
+
+const (
+	// EscalationReminder vuelve a avisar al equipo tratante con mayor urgencia.
+	EscalationReminder = 1
+	// EscalationManagement avisa al dueño del paciente, administradores y gestores de sus organizaciones.
+	EscalationManagement = 2
+)
+
+// IncidentEscalation registra cada paso de escalación de un incidente sin atender.
+type IncidentEscalation struct {
+	ID           uuid.UUID      `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	IncidentID   uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex:idx_incident_escalation_level"`
+	Level        int            `gorm:"not null;uniqueIndex:idx_incident_escalation_level"`
+	RecipientIDs pq.StringArray `gorm:"type:text[]"`
+	CreatedAt    time.Time      `gorm:"autoCreateTime"`
+}
+
+// IncidentAction es una acción de seguimiento asignada a un miembro del equipo.
+type IncidentAction struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	IncidentID  uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Description string     `gorm:"type:text;not null"`
+	AssigneeID  uuid.UUID  `gorm:"type:uuid;not null;index"`
+	DueDate     *time.Time `gorm:"type:date"`
+	CompletedAt *time.Time
+	CreatedByID uuid.UUID `gorm:"type:uuid;not null"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	Assignee    User      `gorm:"foreignKey:AssigneeID"`
+}
+
+type UpdateIncidentInput struct {
+	Severity  string `json:"severity" binding:"omitempty,oneof=LOW MODERATE HIGH CRITICAL"`
+	Category  string `json:"category" binding:"omitempty,oneof=FALL SEIZURE BEHAVIORAL MEDICATION_ERROR INJURY OTHER"`
+	RootCause string `json:"root_cause"`
+}
+
+type ChangeIncidentStatusInput struct {
+	Status    string `json:"status" binding:"required,oneof=ACKNOWLEDGED UNDER_REVIEW CLOSED"`
+	RootCause string `json:"root_cause"` // Requerido para cerrar si aún no se registró
+}
+
+type CreateIncidentActionInput struct {
+	Description string `json:"description" binding:"required"`
+	AssigneeID  string `json:"assignee_id"` // Por defecto, el usuario actual
+	DueDate     string `json:"due_date"`    // YYYY-MM-DD
+}
+
+type UpdateIncidentActionInput struct {
+	Description string `json:"description"`
+	DueDate     string `json:"due_date"`
+	Completed   *bool  `json:"completed"`
+}
@@ -0,0 +1,109 @@
+package domains
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type MedicationRoute string
+
+const (
+	RouteOral          MedicationRoute = "ORAL"
+	RouteSublingual    MedicationRoute = "SUBLINGUAL"
+	RouteTopical       MedicationRoute = "TOPICAL"
+	RouteInhaled       MedicationRoute = "INHALED"
+	RouteIntravenous   MedicationRoute = "INTRAVENOUS"
+	RouteIntramuscular MedicationRoute = "INTRAMUSCULAR"
+	RouteSubcutaneous  MedicationRoute = "SUBCUTANEOUS"
+	RouteRectal        MedicationRoute = "RECTAL"
+	RouteEnteral       MedicationRoute = "ENTERAL" // sonda / gastrostomía
+	RouteOther         MedicationRoute = "OTHER"
+)
+
+type MedicationStatus string
+
+const (
+	MedicationActive  MedicationStatus = "ACTIVE"
+	MedicationStopped MedicationStatus = "STOPPED"
+)
+
+type MedicationAction string
+
+const (
+	MedicationAdministered MedicationAction = "ADMINISTERED"
+	MedicationStarted      MedicationAction = "STARTED"
+	MedicationChanged      MedicationAction = "CHANGED"
+	MedicationStoppedEvent MedicationAction = "STOPPED"
+)
+
+type PatientMedication struct {
+	ID             uuid.UUID        `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	PatientID      uuid.UUID        `gorm:"type:uuid;not null;index"`
+	DrugName       string           `gorm:"type:varchar(255);not null"`
+	Dose           string           `gorm:"type:varchar(100);not null"`
+	Route          MedicationRoute  `gorm:"type:varchar(30);not null"`
+	Frequency      string           `gorm:"type:varchar(100);not null"`
+	PrescriberName string           `gorm:"type:varchar(255)"`
+	StartDate      time.Time        `gorm:"type:date;not null"`
+	EndDate        *time.Time       `gorm:"type:date"`
+	Status         MedicationStatus `gorm:"type:varchar(20);default:'ACTIVE';not null;index"`
+	StopReason     string           `gorm:"type:text"`
+	Notes          string           `gorm:"type:text"`
+	RecordedByID   uuid.UUID        `gorm:"type:uuid;not null"`
+	CreatedAt      time.Time        `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time        `gorm:"autoUpdateTime"`
+	DeletedAt      gorm.DeletedAt   `gorm:"index"`
+	RecordedBy     User             `gorm:"foreignKey:RecordedByID"`
+}
+
+// SessionMedicationEvent registra lo que ocurrió con un medicamento durante una sesión.
+type SessionMedicationEvent struct {
+	ID           uuid.UUID         `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	SessionID    uuid.UUID         `gorm:"type:uuid;not null;index"`
+	PatientID    uuid.UUID         `gorm:"type:uuid;not null;index"`
+	MedicationID uuid.UUID         `gorm:"type:uuid;not null;index"`
+	Action       MedicationAction  `gorm:"type:varchar(20);not null"`
+	Dose         string            `gorm:"type:varchar(100)"`
+	Notes        string            `gorm:"type:text"`
+	CreatedAt    time.Time         `gorm:"autoCreateTime"`
+	Medication   PatientMedication `gorm:"foreignKey:MedicationID"`
+}
+
+type CreateMedicationInput struct {
+	DrugName       string `json:"drug_name" binding:"required"`
+	Dose           string `json:"dose" binding:"required"`
+	Route          string `json:"route" binding:"required,oneof=ORAL SUBLINGUAL TOPICAL INHALED INTRAVENOUS INTRAMUSCULAR SUBCUTANEOUS RECTAL ENTERAL OTHER"`
+	Frequency      string `json:"frequency" binding:"required"`
+	PrescriberName string `json:"prescriber_name"`
+	StartDate      string `json:"start_date"` // YYYY-MM-DD, por defecto hoy
+	EndDate        string `json:"end_date"`   // YYYY-MM-DD
+	Notes          string `json:"notes"`
+}
+
+type UpdateMedicationInput struct {
+	Dose           string `json:"dose"`
+	Route          string `json:"route" binding:"omitempty,oneof=ORAL SUBLINGUAL TOPICAL INHALED INTRAVENOUS INTRAMUSCULAR SUBCUTANEOUS RECTAL ENTERAL OTHER"`
+	Frequency      string `json:"frequency"`
+	PrescriberName string `json:"prescriber_name"`
+	EndDate        string `json:"end_date"` // YYYY-MM-DD
+	Notes          string `json:"notes"`
+}
+
+type StopMedicationInput struct {
+	Reason  string `json:"reason" binding:"required"`
+	EndDate string `json:"end_date"` // YYYY-MM-DD, por defecto hoy
+}
+
+// SessionMedicationInput permite registrar desde la sesión un medicamento
+// administrado, iniciado, modificado o suspendido.
+type SessionMedicationInput struct {
+	Action       string `json:"action" binding:"required,oneof=ADMINISTERED STARTED CHANGED STOPPED"`
+	MedicationID string `json:"medication_id"` // requerido salvo en STARTED
+	DrugName     string `json:"drug_name"`     // solo STARTED
+	Dose         string `json:"dose"`
+	Route        string `json:"route" binding:"omitempty,oneof=ORAL SUBLINGUAL TOPICAL INHALED INTRAVENOUS INTRAMUSCULAR SUBCUTANEOUS RECTAL ENTERAL OTHER"`
+	Frequency    string `json:"frequency"`
+	Notes        string `json:"notes"`
+}
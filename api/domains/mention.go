@@ -0,0 +1,27 @@
+package domains
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MentionFieldComment es el campo de las menciones hechas en comentarios. Las de
+// sesiones usan el nombre JSON del campo de texto (ej: next_session_notes).
+const MentionFieldComment = "comment"
+
+// Mention registra que un miembro del equipo tratante fue mencionado con "@" en
+// una sesión o comentario. Queda pendiente hasta que el mencionado la resuelve.
+type Mention struct {
+	ID              uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	MentionedUserID uuid.UUID  `gorm:"type:uuid;not null;index"`
+	AuthorID        uuid.UUID  `gorm:"type:uuid;not null"`
+	PatientID       uuid.UUID  `gorm:"type:uuid;not null;index"`
+	SessionID       *uuid.UUID `gorm:"type:uuid;index"`
+	CommentID       *uuid.UUID `gorm:"type:uuid;index"`
+	Field           string     `gorm:"type:varchar(40);not null"`
+	Excerpt         string     `gorm:"type:text"`
+	ResolvedAt      *time.Time
+	CreatedAt       time.Time `gorm:"autoCreateTime;index"`
+	Author          User      `gorm:"foreignKey:AuthorID"`
+}
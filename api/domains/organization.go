@@ -0,0 +1,42 @@
+package domains
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type OrgRole string
+
+const (
+	OrgManager OrgRole = "MANAGER"
+	OrgMember  OrgRole = "MEMBER"
+)
+
+type Organization struct {
+	ID        uuid.UUID      `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Name      string         `gorm:"type:varchar(255);not null"`
+	CreatedAt time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+type OrganizationMember struct {
+	ID             uuid.UUID    `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	OrganizationID uuid.UUID    `gorm:"type:uuid;not null;uniqueIndex:idx_org_member"`
+	UserID         uuid.UUID    `gorm:"type:uuid;not null;uniqueIndex:idx_org_member;index"`
+	Role           OrgRole      `gorm:"type:varchar(20);default:'MEMBER';not null"`
+	CreatedAt      time.Time    `gorm:"autoCreateTime"`
+	User           User         `gorm:"foreignKey:UserID"`
+	Organization   Organization `gorm:"foreignKey:OrganizationID"`
+}
+
+type CreateOrganizationInput struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type AddOrganizationMemberInput struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role" binding:"omitempty,oneof=MANAGER MEMBER"`
+}
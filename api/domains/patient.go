@@ -8,6 +8,47 @@ import (
 	"gorm.io/gorm"
 )
 
+type PatientStatus string
+
+const (
+	PatientActive     PatientStatus = "ACTIVE"
+	PatientOnHold     PatientStatus = "ON_HOLD"
+	PatientDischarged PatientStatus = "DISCHARGED"
+	PatientDeceased   PatientStatus = "DECEASED"
+)
+
+// patientTransitions define a qué estados puede pasar un paciente desde cada estado.
+var patientTransitions = map[PatientStatus][]PatientStatus{
+	PatientActive:     {PatientOnHold, PatientDischarged, PatientDeceased},
+	PatientOnHold:     {PatientActive, PatientDischarged, PatientDeceased},
+	PatientDischarged: {PatientActive, PatientDeceased},
+	PatientDeceased:   {},
+}
+
+func (s PatientStatus) IsValid() bool {
+	_, ok := patientTransitions[s]
+	return ok
+}
+
+func (s PatientStatus) CanTransitionTo(next PatientStatus) bool {
+	for _, allowed := range patientTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// AcceptsNewSessions indica si se pueden registrar sesiones nuevas.
+// Un paciente dado de alta debe ser reactivado antes de volver a atenderse.
+func (s PatientStatus) AcceptsNewSessions() bool {
+	return s == PatientActive || s == PatientOnHold
+}
+
+// PatientRUTSQL normaliza el RUT guardado en PersonalInfo igual que utils.NormalizeRUT.
+// Lo usan la detección de duplicados y su índice único.
+const PatientRUTSQL = "upper(replace(replace(replace(personal_info->>'rut', '.', ''), '-', ''), ' ', ''))"
+
 type Patient struct {
 	ID               uuid.UUID      `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
 	CreatorID        uuid.UUID      `gorm:"type:uuid;not null;index"`
@@ -15,11 +56,26 @@ type Patient struct {
 	DisabilityReport string         `gorm:"type:text"`
 	CareNotes        string         `gorm:"type:text"`
 	ConsentPDFUrl    string         `gorm:"type:text;not null"`
+	Status           PatientStatus  `gorm:"type:varchar(20);default:'ACTIVE';not null;index"`
+	StatusReason     string         `gorm:"type:text"`
+	DischargedAt     *time.Time     `gorm:"type:date"`
 	CreatedAt        time.Time      `gorm:"autoCreateTime"`
 	UpdatedAt        time.Time      `gorm:"autoUpdateTime"`
 	DeletedAt        gorm.DeletedAt `gorm:"index"`
 }
 
+type PatientStatusHistory struct {
+	ID            uuid.UUID     `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	PatientID     uuid.UUID     `gorm:"type:uuid;not null;index"`
+	FromStatus    PatientStatus `gorm:"type:varchar(20);not null"`
+	ToStatus      PatientStatus `gorm:"type:varchar(20);not null"`
+	Reason        string        `gorm:"type:text"`
+	EffectiveDate time.Time     `gorm:"type:date;not null"`
+	ChangedByID   uuid.UUID     `gorm:"type:uuid;not null"`
+	CreatedAt     time.Time     `gorm:"autoCreateTime"`
+	ChangedBy     User          `gorm:"foreignKey:ChangedByID"`
+}
+
 type CreatePatientInput struct {
 	FirstName        string         `form:"first_name" binding:"required"`
 	LastName         string         `form:"last_name" binding:"required"`
@@ -29,3 +85,18 @@ type CreatePatientInput struct {
 	CareNotes        string         `form:"care_notes"`
 	PersonalInfo     datatypes.JSON `gorm:"type:jsonb;not null;column:personal_info"`
 }
+
+type DischargePatientInput struct {
+	Reason string `json:"reason" binding:"required"`
+	Date   string `json:"date" binding:"required"` // YYYY-MM-DD
+}
+
+type ReactivatePatientInput struct {
+	Reason string `json:"reason"`
+}
+
+type ChangePatientStatusInput struct {
+	Status string `json:"status" binding:"required,oneof=ON_HOLD DECEASED"`
+	Reason string `json:"reason"`
+	Date   string `json:"date"` // YYYY-MM-DD, por defecto hoy
+}
@@ -0,0 +1,27 @@
+package domains
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// SessionDraft es una sesión a medio escribir. Vive en su propia tabla para que
+// no aparezca en listados, conteos ni en el contexto de IA hasta que se envía.
+// Version permite detectar autoguardados desde otro dispositivo.
+type SessionDraft struct {
+	ID             uuid.UUID      `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	ProfessionalID uuid.UUID      `gorm:"type:uuid;not null;index"`
+	PatientID      *uuid.UUID     `gorm:"type:uuid;index"`
+	Payload        datatypes.JSON `gorm:"type:jsonb;not null"` // CreateSessionInput parcial
+	Version        int            `gorm:"not null;default:1"`
+	CreatedAt      time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time      `gorm:"autoUpdateTime"`
+}
+
+type SaveSessionDraftInput struct {
+	Payload json.RawMessage `json:"payload" binding:"required" swaggertype:"object"`
+	Version int             `json:"version"` // Versión que editó el cliente; requerido al autoguardar
+}
@@ -0,0 +1,68 @@
+package domains
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type TemplateFieldType string
+
+const (
+	FieldText    TemplateFieldType = "TEXT"
+	FieldNumber  TemplateFieldType = "NUMBER"
+	FieldBoolean TemplateFieldType = "BOOLEAN"
+	FieldSelect  TemplateFieldType = "SELECT"
+	FieldDate    TemplateFieldType = "DATE"
+)
+
+// TemplateSection es un bloque guía de la descripción de la sesión (ej: "Motricidad fina").
+type TemplateSection struct {
+	Title string `json:"title" binding:"required"`
+	Hint  string `json:"hint"`
+}
+
+// TemplateField es un campo estructurado propio de la especialidad
+// (ej: "rango_movimiento_hombro" en kinesiología).
+type TemplateField struct {
+	Key      string            `json:"key" binding:"required,max=50"`
+	Label    string            `json:"label" binding:"required"`
+	Type     TemplateFieldType `json:"type" binding:"required,oneof=TEXT NUMBER BOOLEAN SELECT DATE"`
+	Required bool              `json:"required"`
+	Options  []string          `json:"options,omitempty"` // Solo SELECT
+	Min      *float64          `json:"min,omitempty"`     // Solo NUMBER
+	Max      *float64          `json:"max,omitempty"`
+	Unit     string            `json:"unit,omitempty"`
+}
+
+// SessionTemplate define la estructura de las sesiones de una especialidad.
+// Como las etiquetas, puede ser personal o compartida con una organización.
+type SessionTemplate struct {
+	ID                      uuid.UUID      `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Name                    string         `gorm:"type:varchar(255);not null"`
+	Specialty               string         `gorm:"type:varchar(100);index"`
+	Description             string         `gorm:"type:text"`
+	OwnerID                 uuid.UUID      `gorm:"type:uuid;not null;index"`
+	OrganizationID          *uuid.UUID     `gorm:"type:uuid;index"`
+	Sections                datatypes.JSON `gorm:"type:jsonb"` // []TemplateSection
+	DefaultInterventionPlan string         `gorm:"type:text"`
+	RequiredFields          pq.StringArray `gorm:"type:text[]"` // Campos estándar de la sesión que pasan a ser obligatorios
+	ExtraFields             datatypes.JSON `gorm:"type:jsonb"`  // []TemplateField
+	CreatedAt               time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt               time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt               gorm.DeletedAt `gorm:"index"`
+}
+
+type SessionTemplateInput struct {
+	Name                    string            `json:"name" binding:"required"`
+	Specialty               string            `json:"specialty"`
+	Description             string            `json:"description"`
+	OrganizationID          string            `json:"organization_id"` // si viene, la plantilla es de la organización
+	Sections                []TemplateSection `json:"sections" binding:"omitempty,dive"`
+	DefaultInterventionPlan string            `json:"default_intervention_plan"`
+	RequiredFields          []string          `json:"required_fields" binding:"omitempty,dive,oneof=vitals achievements patient_performance photos next_session_notes"`
+	ExtraFields             []TemplateField   `json:"extra_fields" binding:"omitempty,dive"`
+}
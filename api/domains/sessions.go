@@ -24,22 +24,50 @@ type Session struct {
 	IncidentDetails    string         `gorm:"type:text"`
 	IncidentPhoto      string         `gorm:"type:text"`
 	NextSessionNotes   string         `gorm:"type:text"`
+	AppointmentID      *uuid.UUID     `gorm:"type:uuid;index"`
+	TemplateID         *uuid.UUID     `gorm:"type:uuid;index"`
+	GroupSessionID     *uuid.UUID     `gorm:"type:uuid;index"`    // Sesión grupal de la que forma parte
+	ExtraFields        datatypes.JSON `gorm:"type:jsonb"`         // Campos estructurados de la plantilla
+	Version            int            `gorm:"not null;default:1"` // Sube en cada edición; la sincronización offline detecta conflictos con ella
+	CosignStatus       CosignStatus   `gorm:"type:varchar(20);default:'NOT_REQUIRED';not null;index"`
+	CosignedByID       *uuid.UUID     `gorm:"type:uuid"`
+	CosignedAt         *time.Time
+	CosignComments     string `gorm:"type:text"` // Observaciones del supervisor al devolver la sesión
 
 	CreatedAt time.Time `gorm:"index"`
 	UpdatedAt time.Time
 	DeletedAt gorm.DeletedAt `gorm:"index"`
 }
 
+// SessionSearchVectorSQL es el documento de búsqueda de texto completo de una
+// sesión. El índice idx_sessions_search usa la misma expresión.
+const SessionSearchVectorSQL = "to_tsvector('spanish', coalesce(description, '') || ' ' || coalesce(intervention_plan, '') || ' ' || coalesce(achievements, '') || ' ' || coalesce(next_session_notes, ''))"
+
+// SessionListItem es una sesión en los listados: en vez del usuario autor completo
+// (con su ProfileData) lleva solo su nombre visible.
+type SessionListItem struct {
+	Session
+	Creator    *User  `json:"Creator,omitempty"` // Oculta Session.Creator; siempre nil
+	AuthorName string `json:"author_name"`
+}
+
 type CreateSessionInput struct {
-	PatientID          string                 `json:"patient_id" binding:"required"`
-	InterventionPlan   string                 `json:"intervention_plan" binding:"required"`
-	Vitals             map[string]interface{} `json:"vitals"`
-	Description        string                 `json:"description" binding:"required"`
-	Achievements       string                 `json:"achievements"`
-	PatientPerformance string                 `json:"patient_performance"`
-	Photos             []string               `json:"photos"`
-	HasIncident        bool                   `json:"has_incident"`
-	IncidentDetails    string                 `json:"incident_details"`
-	IncidentPhoto      string                 `json:"incident_photo"`
-	NextSessionNotes   string                 `json:"next_session_notes"`
+	PatientID          string                   `json:"patient_id" binding:"required"`
+	InterventionPlan   string                   `json:"intervention_plan"` // Requerido, salvo que la plantilla tenga uno por defecto
+	Vitals             map[string]interface{}   `json:"vitals"`            // Claves de VitalsCatalog; se validan y guardan como números
+	Description        string                   `json:"description" binding:"required"`
+	Achievements       string                   `json:"achievements"`
+	PatientPerformance string                   `json:"patient_performance"`
+	Photos             []string                 `json:"photos"`
+	HasIncident        bool                     `json:"has_incident"`
+	IncidentDetails    string                   `json:"incident_details"`
+	IncidentPhoto      string                   `json:"incident_photo"`
+	IncidentSeverity   string                   `json:"incident_severity" binding:"omitempty,oneof=LOW MODERATE HIGH CRITICAL"`
+	IncidentCategory   string                   `json:"incident_category" binding:"omitempty,oneof=FALL SEIZURE BEHAVIORAL MEDICATION_ERROR INJURY OTHER"`
+	NextSessionNotes   string                   `json:"next_session_notes"`
+	Medications        []SessionMedicationInput `json:"medications" binding:"omitempty,dive"`
+	GoalProgress       []GoalProgressInput      `json:"goal_progress" binding:"omitempty,dive"`
+	AppointmentID      string                   `json:"appointment_id"` // Completa la cita al registrar la sesión
+	TemplateID         string                   `json:"template_id"`
+	ExtraFields        map[string]interface{}   `json:"extra_fields"` // Se validan contra la plantilla
 }
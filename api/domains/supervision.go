@@ -0,0 +1,49 @@
+package domains
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CosignStatus string
+
+const (
+	CosignNotRequired CosignStatus = "NOT_REQUIRED"
+	CosignPending     CosignStatus = "PENDING"
+	CosignApproved    CosignStatus = "APPROVED"
+	CosignReturned    CosignStatus = "RETURNED"
+)
+
+func (s CosignStatus) IsValid() bool {
+	switch s {
+	case CosignNotRequired, CosignPending, CosignApproved, CosignReturned:
+		return true
+	}
+	return false
+}
+
+// AwaitingCosign indica si la sesión todavía no cuenta con la firma del supervisor.
+func (s CosignStatus) AwaitingCosign() bool {
+	return s == CosignPending || s == CosignReturned
+}
+
+// Supervision asigna un profesional supervisor a un usuario en práctica (ej: internos
+// universitarios). Las sesiones del supervisado requieren su co-firma.
+type Supervision struct {
+	ID           uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	SupervisorID uuid.UUID `gorm:"type:uuid;not null;index"`
+	TraineeID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"` // Un supervisor por usuario
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+	Supervisor   User      `gorm:"foreignKey:SupervisorID"`
+	Trainee      User      `gorm:"foreignKey:TraineeID"`
+}
+
+type CreateSupervisionInput struct {
+	SupervisorEmail string `json:"supervisor_email" binding:"required,email"`
+	TraineeEmail    string `json:"trainee_email" binding:"required,email"`
+}
+
+type ReturnSessionInput struct {
+	Comments string `json:"comments" binding:"required"`
+}
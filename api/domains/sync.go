@@ -0,0 +1,80 @@
+package domains
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SyncItemKind string
+
+const (
+	SyncKindSession  SyncItemKind = "SESSION"
+	SyncKindDocument SyncItemKind = "DOCUMENT"
+	SyncKindUpload   SyncItemKind = "UPLOAD"
+)
+
+type SyncStatus string
+
+const (
+	SyncCreated   SyncStatus = "CREATED"
+	SyncUpdated   SyncStatus = "UPDATED"
+	SyncDuplicate SyncStatus = "DUPLICATE" // Reintento de una operación ya aplicada
+	SyncConflict  SyncStatus = "CONFLICT"  // El servidor tiene una versión más nueva
+	SyncError     SyncStatus = "ERROR"
+)
+
+// MaxSyncBatchItems limita el tamaño de un lote de sincronización.
+const MaxSyncBatchItems = 100
+
+// SyncReceipt guarda el resultado de cada operación sincronizada, para que los
+// reintentos del cliente (misma client_id) no dupliquen datos.
+type SyncReceipt struct {
+	ClientID  uuid.UUID    `gorm:"type:uuid;primaryKey"`
+	UserID    uuid.UUID    `gorm:"type:uuid;not null;index"`
+	Kind      SyncItemKind `gorm:"type:varchar(20);not null"`
+	EntityID  *uuid.UUID   `gorm:"type:uuid"`
+	Path      string       `gorm:"type:text"` // Archivos subidos
+	Status    SyncStatus   `gorm:"type:varchar(20);not null"`
+	Version   int
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+// SyncSessionItem es una sesión creada o editada sin conexión. El client_id identifica
+// la operación; session_id es el ID de la sesión, generado en el dispositivo al crearla.
+type SyncSessionItem struct {
+	ClientID    string             `json:"client_id" binding:"required,uuid"`
+	SessionID   string             `json:"session_id" binding:"required,uuid"`
+	BaseVersion int                `json:"base_version"` // 0 al crear; versión editada al actualizar
+	RecordedAt  *time.Time         `json:"recorded_at"`  // Momento real de la sesión en el dispositivo
+	Data        CreateSessionInput `json:"data"`
+}
+
+// SyncDocumentItem registra un documento cuyo archivo ya se subió con /uploads/document.
+type SyncDocumentItem struct {
+	ClientID    string `json:"client_id" binding:"required,uuid"`
+	DocumentID  string `json:"document_id" binding:"required,uuid"`
+	PatientID   string `json:"patient_id" binding:"required,uuid"`
+	Name        string `json:"name" binding:"required"`
+	Category    string `json:"category" binding:"required,oneof=LAB IMAGING PRESCRIPTION OTHER"`
+	Date        string `json:"date" binding:"required"`
+	Description string `json:"description"`
+	FilePath    string `json:"file_path" binding:"required"`
+}
+
+// SyncBatchInput no valida los ítems al bindear: cada uno se valida por separado
+// para informar el error en su resultado sin rechazar el lote completo.
+type SyncBatchInput struct {
+	Sessions  []SyncSessionItem  `json:"sessions"`
+	Documents []SyncDocumentItem `json:"documents"`
+}
+
+type SyncItemResult struct {
+	ClientID string       `json:"client_id"`
+	Kind     SyncItemKind `json:"kind"`
+	EntityID *uuid.UUID   `json:"entity_id,omitempty"`
+	Status   SyncStatus   `json:"status"`
+	Version  int          `json:"version,omitempty"`
+	Error    string       `json:"error,omitempty"`
+	Server   interface{}  `json:"server,omitempty"` // Copia del servidor en CONFLICT
+}
@@ -0,0 +1,72 @@
+package domains
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type TagScope string
+
+const (
+	TagScopeUser         TagScope = "USER"
+	TagScopeOrganization TagScope = "ORGANIZATION"
+)
+
+// Tag agrupa pacientes por programa, sede o fuente de financiamiento.
+// Las etiquetas USER solo las ve su dueño; las ORGANIZATION, todos los miembros.
+type Tag struct {
+	ID             uuid.UUID      `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Name           string         `gorm:"type:varchar(100);not null"`
+	Color          string         `gorm:"type:varchar(20)"`
+	Scope          TagScope       `gorm:"type:varchar(20);default:'USER';not null"`
+	OwnerID        uuid.UUID      `gorm:"type:uuid;not null;index"`
+	OrganizationID *uuid.UUID     `gorm:"type:uuid;index"`
+	CreatedAt      time.Time      `gorm:"autoCreateTime"`
+	DeletedAt      gorm.DeletedAt `gorm:"index"`
+}
+
+type PatientTag struct {
+	PatientID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	TagID     uuid.UUID `gorm:"type:uuid;primaryKey;index"`
+	AddedByID uuid.UUID `gorm:"type:uuid;not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	Tag       Tag       `gorm:"foreignKey:TagID"`
+}
+
+// CohortFilters son los filtros guardados de una cohorte.
+type CohortFilters struct {
+	Status string   `json:"status,omitempty"`
+	TagIDs []string `json:"tag_ids,omitempty"`
+}
+
+// SavedCohort es un preset de filtros de pacientes con nombre,
+// reutilizable en listados y dashboards.
+type SavedCohort struct {
+	ID             uuid.UUID      `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Name           string         `gorm:"type:varchar(255);not null"`
+	OwnerID        uuid.UUID      `gorm:"type:uuid;not null;index"`
+	OrganizationID *uuid.UUID     `gorm:"type:uuid;index"`
+	Filters        datatypes.JSON `gorm:"type:jsonb;not null"`
+	CreatedAt      time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt      gorm.DeletedAt `gorm:"index"`
+}
+
+type CreateTagInput struct {
+	Name           string `json:"name" binding:"required"`
+	Color          string `json:"color"`
+	OrganizationID string `json:"organization_id"` // si viene, la etiqueta es de la organización
+}
+
+type AssignTagInput struct {
+	TagID string `json:"tag_id" binding:"required"`
+}
+
+type CreateCohortInput struct {
+	Name           string        `json:"name" binding:"required"`
+	OrganizationID string        `json:"organization_id"`
+	Filters        CohortFilters `json:"filters"`
+}
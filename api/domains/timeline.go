@@ -0,0 +1,74 @@
+package domains
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TimelineEventType string
+
+const (
+	TimelineSession              TimelineEventType = "SESSION"
+	TimelineIncident             TimelineEventType = "INCIDENT"
+	TimelineDocument             TimelineEventType = "DOCUMENT"
+	TimelineReport               TimelineEventType = "REPORT"
+	TimelineCollaborationJoined  TimelineEventType = "COLLABORATION_JOINED"
+	TimelineCollaborationRevoked TimelineEventType = "COLLABORATION_REVOKED"
+	TimelineProfileChange        TimelineEventType = "PROFILE_CHANGE"
+	TimelineStatusChange         TimelineEventType = "STATUS_CHANGE"
+)
+
+var TimelineEventTypes = []TimelineEventType{
+	TimelineSession,
+	TimelineIncident,
+	TimelineDocument,
+	TimelineReport,
+	TimelineCollaborationJoined,
+	TimelineCollaborationRevoked,
+	TimelineProfileChange,
+	TimelineStatusChange,
+}
+
+func (t TimelineEventType) IsValid() bool {
+	for _, valid := range TimelineEventTypes {
+		if t == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// PatientProfileChange registra cada cambio de un campo del perfil del paciente.
+type PatientProfileChange struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	PatientID   uuid.UUID `gorm:"type:uuid;not null;index"`
+	Field       string    `gorm:"type:varchar(100);not null"`
+	OldValue    string    `gorm:"type:text"`
+	NewValue    string    `gorm:"type:text"`
+	ChangedByID uuid.UUID `gorm:"type:uuid;not null"`
+	CreatedAt   time.Time `gorm:"autoCreateTime;index"`
+	ChangedBy   User      `gorm:"foreignKey:ChangedByID"`
+}
+
+// TimelineEvent es un evento de la línea de tiempo del paciente.
+// SourceID apunta al registro original (sesión, documento, informe...).
+type TimelineEvent struct {
+	Type       TimelineEventType `json:"type"`
+	SourceID   uuid.UUID         `json:"source_id"`
+	OccurredAt time.Time         `json:"occurred_at"`
+	ActorID    *uuid.UUID        `json:"actor_id"`
+	Summary    string            `json:"summary"`
+	Actor      *User             `json:"actor,omitempty" gorm:"-"`
+}
+
+type TimelineFilter struct {
+	Types     []TimelineEventType
+	From      *time.Time
+	To        *time.Time
+	Ascending bool
+	// Cursor: se devuelven eventos estrictamente posteriores a (AfterTime, AfterKey) en el orden pedido
+	AfterTime *time.Time
+	AfterKey  string
+	Limit     int
+}
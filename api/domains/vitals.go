@@ -0,0 +1,106 @@
+package domains
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type VitalKey string
+
+const (
+	VitalBPSystolic  VitalKey = "bp_systolic"
+	VitalBPDiastolic VitalKey = "bp_diastolic"
+	VitalHeartRate   VitalKey = "heart_rate"
+	VitalSpO2        VitalKey = "spo2"
+	VitalTemperature VitalKey = "temperature"
+	VitalWeight      VitalKey = "weight"
+	VitalHeight      VitalKey = "height"
+	VitalGlucose     VitalKey = "glucose"
+	VitalPainScale   VitalKey = "pain_scale"
+)
+
+// VitalDefinition describe un signo vital del catálogo. Min y Max son el rango
+// fisiológicamente plausible: fuera de él se asume un error de tipeo.
+type VitalDefinition struct {
+	Key     VitalKey `json:"key"`
+	Label   string   `json:"label"`
+	Unit    string   `json:"unit"`
+	Min     float64  `json:"min"`
+	Max     float64  `json:"max"`
+	Integer bool     `json:"integer"`
+}
+
+var VitalsCatalog = []VitalDefinition{
+	{Key: VitalBPSystolic, Label: "Presión arterial sistólica", Unit: "mmHg", Min: 50, Max: 260, Integer: true},
+	{Key: VitalBPDiastolic, Label: "Presión arterial diastólica", Unit: "mmHg", Min: 30, Max: 160, Integer: true},
+	{Key: VitalHeartRate, Label: "Frecuencia cardíaca", Unit: "lpm", Min: 20, Max: 250, Integer: true},
+	{Key: VitalSpO2, Label: "Saturación de oxígeno", Unit: "%", Min: 50, Max: 100, Integer: true},
+	{Key: VitalTemperature, Label: "Temperatura", Unit: "°C", Min: 30, Max: 45},
+	{Key: VitalWeight, Label: "Peso", Unit: "kg", Min: 0.5, Max: 350},
+	{Key: VitalHeight, Label: "Talla", Unit: "cm", Min: 30, Max: 250},
+	{Key: VitalGlucose, Label: "Glicemia", Unit: "mg/dL", Min: 20, Max: 800, Integer: true},
+	{Key: VitalPainScale, Label: "Escala de dolor (EVA)", Unit: "0-10", Min: 0, Max: 10, Integer: true},
+}
+
+func LookupVital(key VitalKey) (VitalDefinition, bool) {
+	for _, def := range VitalsCatalog {
+		if def.Key == key {
+			return def, true
+		}
+	}
+	return VitalDefinition{}, false
+}
+
+// VitalThreshold es el rango aceptable de un signo vital para un paciente.
+// Los valores fuera del rango generan una notificación al equipo tratante.
+type VitalThreshold struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	PatientID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_vital_threshold_patient_key"`
+	VitalKey    VitalKey  `gorm:"type:varchar(30);not null;uniqueIndex:idx_vital_threshold_patient_key"`
+	Min         *float64
+	Max         *float64
+	CreatedByID uuid.UUID `gorm:"type:uuid;not null"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+}
+
+type SetVitalThresholdInput struct {
+	VitalKey string   `json:"vital_key" binding:"required"`
+	Min      *float64 `json:"min"`
+	Max      *float64 `json:"max"`
+}
+
+// AbnormalVital es un valor registrado fuera del umbral del paciente.
+type AbnormalVital struct {
+	Key   VitalKey `json:"key"`
+	Label string   `json:"label"`
+	Unit  string   `json:"unit"`
+	Value float64  `json:"value"`
+	Min   *float64 `json:"min"`
+	Max   *float64 `json:"max"`
+}
+
+// VitalTrendInterval agrupa las mediciones. Vacío devuelve cada medición.
+type VitalTrendInterval string
+
+const (
+	VitalTrendRaw  VitalTrendInterval = ""
+	VitalTrendDay  VitalTrendInterval = "day"
+	VitalTrendWeek VitalTrendInterval = "week"
+)
+
+type VitalTrendPoint struct {
+	Bucket time.Time `json:"bucket"` // Fecha de la sesión, o inicio del día/semana
+	Min    float64   `json:"min"`
+	Max    float64   `json:"max"`
+	Avg    float64   `json:"avg"`
+	Count  int       `json:"count"`
+}
+
+type VitalSeries struct {
+	Key    VitalKey          `json:"key"`
+	Label  string            `json:"label"`
+	Unit   string            `json:"unit"`
+	Points []VitalTrendPoint `json:"points"`
+}
@@ -1,13 +1,16 @@
 package admin
 
 import (
+	"errors"
 	"net/http"
 	"time"
 
 	"bitacora-medica-backend/api/database"
 	"bitacora-medica-backend/api/domains"
+	"bitacora-medica-backend/api/services"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 func GetDashboardStatsHandler() gin.HandlerFunc {
@@ -15,11 +18,50 @@ func GetDashboardStatsHandler() gin.HandlerFunc {
 		db := database.GetDB()
 		stats := domains.AdminDashboardStats{}
 
+		// El contador de pacientes muestra ACTIVE salvo que se pida otro estado
+		patientStatus := domains.PatientActive
+		if status := c.Query("status"); status != "" {
+			if !domains.PatientStatus(status).IsValid() {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status. Options: ACTIVE, ON_HOLD, DISCHARGED, DECEASED"})
+				return
+			}
+			patientStatus = domains.PatientStatus(status)
+		}
+
+		// Con cohort_id, las métricas de pacientes y sesiones se limitan a la cohorte
+		var cohortPatients *gorm.DB
+		if cohortID := c.Query("cohort_id"); cohortID != "" {
+			currentUser := c.MustGet("currentUser").(domains.User)
+			scope, err := services.CohortScope(db, currentUser, cohortID)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Cohort not found"})
+				return
+			}
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			cohortPatients = scope
+		}
+
+		patientScope := func() *gorm.DB {
+			query := db.Model(&domains.Patient{})
+			if cohortPatients != nil {
+				query = query.Where("id IN (?)", cohortPatients)
+			}
+			return query
+		}
+
 		// 1. Contadores Globales
 		db.Model(&domains.User{}).Count(&stats.TotalUsers)
 		db.Model(&domains.User{}).Where("status = ?", "INACTIVE").Count(&stats.PendingUsers)
-		db.Model(&domains.Patient{}).Count(&stats.ActivePatients)
-		db.Model(&domains.Session{}).Count(&stats.TotalSessions)
+		patientScope().Where("status = ?", patientStatus).Count(&stats.ActivePatients)
+		sessionQuery := db.Model(&domains.Session{})
+		if cohortPatients != nil {
+			sessionQuery = sessionQuery.Where("patient_id IN (?)", cohortPatients)
+		}
+		sessionQuery.Count(&stats.TotalSessions)
+		stats.PatientsByStatus = services.CountPatientsByStatus(patientScope())
 
 		// 2. Gráfico de Crecimiento
 
@@ -68,9 +110,21 @@ func GetDashboardStatsHandler() gin.HandlerFunc {
 			})
 		}
 
+		// 3. Pacientes por grupo diagnóstico (solo diagnósticos activos)
+		var diagnosisGroups []domains.DiagnosisGroupStats
+		groupsQuery := db.Table("patient_diagnoses d").
+			Select("d.group_code, d.group_name, count(DISTINCT d.patient_id) as patients").
+			Joins("JOIN patients p ON p.id = d.patient_id AND p.deleted_at IS NULL").
+			Where("d.status = ? AND d.deleted_at IS NULL AND p.status = ?", domains.DiagnosisActive, patientStatus)
+		if cohortPatients != nil {
+			groupsQuery = groupsQuery.Where("p.id IN (?)", cohortPatients)
+		}
+		groupsQuery.Group("d.group_code, d.group_name").Order("patients DESC").Scan(&diagnosisGroups)
+
 		c.JSON(http.StatusOK, gin.H{
-			"stats":  stats,
-			"growth": finalGrowth,
+			"stats":            stats,
+			"growth":           finalGrowth,
+			"diagnosis_groups": diagnosisGroups,
 		})
 	}
 }
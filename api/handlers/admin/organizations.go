@@ -0,0 +1,112 @@
+package admin
+
+import (
+	"net/http"
+
+	"bitacora-medica-backend/api/database"
+	"bitacora-medica-backend/api/domains"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ListOrganizationsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var organizations []domains.Organization
+		database.GetDB().Order("name ASC").Find(&organizations)
+		c.JSON(http.StatusOK, gin.H{"data": organizations})
+	}
+}
+
+func CreateOrganizationHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input domains.CreateOrganizationInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		organization := domains.Organization{Name: input.Name}
+		if err := database.GetDB().Create(&organization).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create organization"})
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "Organization created successfully",
+			"data":    organization,
+		})
+	}
+}
+
+func ListOrganizationMembersHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var members []domains.OrganizationMember
+		if err := database.GetDB().Preload("User").
+			Where("organization_id = ?", c.Param("id")).
+			Find(&members).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch members"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"data": members})
+	}
+}
+
+func AddOrganizationMemberHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input domains.AddOrganizationMemberInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		db := database.GetDB()
+		var organization domains.Organization
+		if err := db.First(&organization, "id = ?", c.Param("id")).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Organization not found"})
+			return
+		}
+
+		var user domains.User
+		if err := db.Where("email = ?", input.Email).First(&user).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return
+		}
+
+		role := domains.OrgMember
+		if input.Role != "" {
+			role = domains.OrgRole(input.Role)
+		}
+
+		var member domains.OrganizationMember
+		err := db.Where("organization_id = ? AND user_id = ?", organization.ID, user.ID).
+			Assign(domains.OrganizationMember{Role: role}).
+			FirstOrCreate(&member, domains.OrganizationMember{OrganizationID: organization.ID, UserID: user.ID}).Error
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add member"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Member added successfully",
+			"data":    member,
+		})
+	}
+}
+
+func RemoveOrganizationMemberHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		result := database.GetDB().
+			Where("organization_id = ? AND user_id = ?", c.Param("id"), c.Param("user_id")).
+			Delete(&domains.OrganizationMember{})
+		if result.Error != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove member"})
+			return
+		}
+		if result.RowsAffected == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Member not found"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Member removed successfully"})
+	}
+}
@@ -0,0 +1,84 @@
+package admin
+
+import (
+	"net/http"
+
+	"bitacora-medica-backend/api/database"
+	"bitacora-medica-backend/api/domains"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ListSupervisionsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var supervisions []domains.Supervision
+		if err := database.GetDB().Preload("Supervisor").Preload("Trainee").
+			Order("created_at DESC").
+			Find(&supervisions).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch supervisions"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"data": supervisions})
+	}
+}
+
+// CreateSupervisionHandler asigna (o reemplaza) el supervisor de un usuario.
+func CreateSupervisionHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input domains.CreateSupervisionInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		db := database.GetDB()
+
+		var supervisor, trainee domains.User
+		if err := db.Where("email = ?", input.SupervisorEmail).First(&supervisor).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Supervisor not found"})
+			return
+		}
+		if err := db.Where("email = ?", input.TraineeEmail).First(&trainee).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Trainee not found"})
+			return
+		}
+		if supervisor.ID == trainee.ID {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "A user cannot supervise themselves"})
+			return
+		}
+
+		var supervision domains.Supervision
+		err := db.Where("trainee_id = ?", trainee.ID).
+			Assign(domains.Supervision{SupervisorID: supervisor.ID}).
+			FirstOrCreate(&supervision, domains.Supervision{TraineeID: trainee.ID}).Error
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to assign supervisor"})
+			return
+		}
+		supervision.Supervisor = supervisor
+		supervision.Trainee = trainee
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Supervisor assigned successfully",
+			"data":    supervision,
+		})
+	}
+}
+
+// DeleteSupervisionHandler quita la supervisión. Las sesiones ya pendientes
+// conservan su estado de co-firma.
+func DeleteSupervisionHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		result := database.GetDB().Where("id = ?", c.Param("id")).Delete(&domains.Supervision{})
+		if result.Error != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove supervision"})
+			return
+		}
+		if result.RowsAffected == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Supervision not found"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Supervision removed successfully"})
+	}
+}
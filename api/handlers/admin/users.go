@@ -6,16 +6,35 @@ import (
 	"bitacora-medica-backend/api/config"
 	"bitacora-medica-backend/api/database"
 	"bitacora-medica-backend/api/domains"
+	"bitacora-medica-backend/api/pagination"
 	"bitacora-medica-backend/api/services"
 
 	"github.com/gin-gonic/gin"
 )
 
+var pendingUsersPagination = pagination.Options{
+	Sorts: map[string]pagination.SortField{
+		"created_at": {Column: "created_at", Field: "CreatedAt", IsTime: true},
+	},
+	DefaultSort:  "created_at",
+	DefaultOrder: pagination.Asc,
+}
+
 func ListPendingUsersHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		params, err := pagination.Parse(c, pendingUsersPagination)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
 		var users []domains.User
-		database.GetDB().Where("status = ?", domains.StatusInactive).Find(&users)
-		c.JSON(http.StatusOK, gin.H{"data": users})
+		meta, err := params.Find(database.GetDB().Where("status = ?", domains.StatusInactive), &users)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch users"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"data": users, "meta": meta})
 	}
 }
 
@@ -0,0 +1,39 @@
+package appointments
+
+import (
+	"net/http"
+
+	"bitacora-medica-backend/api/database"
+	"bitacora-medica-backend/api/domains"
+	"bitacora-medica-backend/api/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// loadAppointment carga la cita de :id y verifica que el usuario sea el profesional
+// asignado o parte del equipo del paciente.
+func loadAppointment(c *gin.Context) (domains.Appointment, domains.User, bool) {
+	currentUser := c.MustGet("currentUser").(domains.User)
+	db := database.GetDB()
+
+	var appointment domains.Appointment
+	if err := db.First(&appointment, "id = ?", c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Appointment not found"})
+		return appointment, currentUser, false
+	}
+
+	if appointment.ProfessionalID != currentUser.ID && !services.CanAccessPatient(db, currentUser, appointment.PatientID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You do not have access to this appointment"})
+		return appointment, currentUser, false
+	}
+
+	return appointment, currentUser, true
+}
+
+// respondConflicts responde 409 con las citas que se solapan.
+func respondConflicts(c *gin.Context, conflicts []domains.Appointment) {
+	c.JSON(http.StatusConflict, gin.H{
+		"error":     "The professional already has appointments in that time range",
+		"conflicts": conflicts,
+	})
+}
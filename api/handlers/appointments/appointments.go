@@ -0,0 +1,524 @@
+package appointments
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"bitacora-medica-backend/api/database"
+	"bitacora-medica-backend/api/domains"
+	"bitacora-medica-backend/api/pagination"
+	"bitacora-medica-backend/api/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const maxAppointmentDuration = 12 * time.Hour
+
+var appointmentsPagination = pagination.Options{
+	Sorts: map[string]pagination.SortField{
+		"starts_at": {Column: "starts_at", Field: "StartsAt", IsTime: true},
+	},
+	DefaultSort:  "starts_at",
+	DefaultOrder: pagination.Asc,
+}
+
+func validateSlot(start, end time.Time) string {
+	if !end.After(start) {
+		return "ends_at must be after starts_at"
+	}
+	if end.Sub(start) > maxAppointmentDuration {
+		return "Appointments cannot last more than 12 hours"
+	}
+	return ""
+}
+
+// @Summary      List appointments
+// @Description  Calendar of appointments in a date range. Without patient_id or professional_id, lists the user's own agenda.
+// @Tags         Appointments
+// @Produce      json
+// @Param        from             query     string  false  "From date (YYYY-MM-DD, default today)"
+// @Param        to               query     string  false  "To date, inclusive (YYYY-MM-DD, default from + 30 days)"
+// @Param        patient_id       query     string  false  "Filter by patient"
+// @Param        professional_id  query     string  false  "Filter by professional"
+// @Param        status           query     string  false  "Filter by status"
+// @Param        limit            query     int     false  "Page size (max 100)"
+// @Param        cursor           query     string  false  "Cursor from the previous page"
+// @Success      200              {object}  map[string]interface{}
+// @Failure      400              {object}  map[string]string
+// @Router       /appointments [get]
+// @Security     Bearer
+func ListAppointmentsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+		db := database.GetDB()
+
+		params, err := pagination.Parse(c, appointmentsPagination)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		now := time.Now()
+		from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		if v := c.Query("from"); v != "" {
+			if from, err = time.ParseInLocation("2006-01-02", v, now.Location()); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from date format (YYYY-MM-DD)"})
+				return
+			}
+		}
+		to := from.AddDate(0, 0, 30)
+		if v := c.Query("to"); v != "" {
+			if to, err = time.ParseInLocation("2006-01-02", v, now.Location()); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to date format (YYYY-MM-DD)"})
+				return
+			}
+			to = to.AddDate(0, 0, 1)
+		}
+
+		query := db.Preload("Patient").Preload("Professional").
+			Where("starts_at >= ? AND starts_at < ?", from, to).
+			Where(db.Where("patient_id IN (?)", services.AccessiblePatientIDs(db, currentUser.ID)).
+				Or("professional_id = ?", currentUser.ID))
+
+		patientID := c.Query("patient_id")
+		professionalID := c.Query("professional_id")
+		if patientID == "" && professionalID == "" {
+			professionalID = currentUser.ID.String()
+		}
+		if patientID != "" {
+			query = query.Where("patient_id = ?", patientID)
+		}
+		if professionalID != "" {
+			query = query.Where("professional_id = ?", professionalID)
+		}
+		if status := c.Query("status"); status != "" {
+			query = query.Where("status = ?", status)
+		}
+
+		var appointments []domains.Appointment
+		meta, err := params.Find(query, &appointments)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch appointments"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"data": appointments, "meta": meta})
+	}
+}
+
+// @Summary      Get appointment
+// @Tags         Appointments
+// @Produce      json
+// @Param        id   path      string  true  "Appointment ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]string
+// @Router       /appointments/{id} [get]
+// @Security     Bearer
+func GetAppointmentHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		appointment, _, ok := loadAppointment(c)
+		if !ok {
+			return
+		}
+
+		database.GetDB().Preload("Patient").Preload("Professional").First(&appointment, "id = ?", appointment.ID)
+		c.JSON(http.StatusOK, gin.H{"data": appointment})
+	}
+}
+
+// @Summary      Create appointment
+// @Description  Schedule an appointment, optionally repeating weekly. Fails with 409 if any occurrence overlaps another appointment of the professional.
+// @Tags         Appointments
+// @Accept       json
+// @Produce      json
+// @Param        input  body      domains.CreateAppointmentInput  true  "Appointment Data"
+// @Success      201    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]string
+// @Failure      403    {object}  map[string]string
+// @Failure      409    {object}  map[string]interface{}
+// @Router       /appointments [post]
+// @Security     Bearer
+func CreateAppointmentHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+
+		var input domains.CreateAppointmentInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if msg := validateSlot(input.StartsAt, input.EndsAt); msg != "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+			return
+		}
+
+		patientID, err := uuid.Parse(input.PatientID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Patient ID"})
+			return
+		}
+
+		db := database.GetDB()
+		if !services.CanAccessPatient(db, currentUser, patientID) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have access to this patient"})
+			return
+		}
+
+		var patient domains.Patient
+		if err := db.Select("id", "status").First(&patient, "id = ?", patientID).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Patient not found"})
+			return
+		}
+		if !patient.Status.AcceptsNewSessions() {
+			c.JSON(http.StatusConflict, gin.H{"error": "Patient is " + string(patient.Status) + ". Reactivate the patient before scheduling."})
+			return
+		}
+
+		professionalID := currentUser.ID
+		if input.ProfessionalID != "" {
+			if professionalID, err = uuid.Parse(input.ProfessionalID); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Professional ID"})
+				return
+			}
+			var professional domains.User
+			if err := db.First(&professional, "id = ?", professionalID).Error; err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Professional not found"})
+				return
+			}
+			if !services.CanAccessPatient(db, professional, patientID) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "The professional must be part of the patient's care team"})
+				return
+			}
+		}
+
+		slots, rule, err := services.ExpandRecurrence(services.TimeSlot{Start: input.StartsAt, End: input.EndsAt}, input.Recurrence)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		modality := domains.ModalityInPerson
+		if input.Modality != "" {
+			modality = domains.AppointmentModality(input.Modality)
+		}
+
+		var seriesID *uuid.UUID
+		if len(slots) > 1 {
+			id := uuid.New()
+			seriesID = &id
+		}
+
+		appointments := make([]domains.Appointment, 0, len(slots))
+		for _, slot := range slots {
+			appointments = append(appointments, domains.Appointment{
+				PatientID:      patientID,
+				ProfessionalID: professionalID,
+				StartsAt:       slot.Start,
+				EndsAt:         slot.End,
+				Modality:       modality,
+				Location:       input.Location,
+				Notes:          input.Notes,
+				Status:         domains.AppointmentScheduled,
+				SeriesID:       seriesID,
+				RecurrenceRule: rule,
+				CreatedByID:    currentUser.ID,
+			})
+		}
+
+		// La verificación de conflictos y la inserción van juntas, con la agenda bloqueada
+		var conflicts []domains.Appointment
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := services.LockProfessionalSchedule(tx, professionalID); err != nil {
+				return err
+			}
+			var err error
+			conflicts, err = services.FindAppointmentConflicts(tx, professionalID, slots)
+			if err != nil || len(conflicts) > 0 {
+				return err
+			}
+			return tx.Create(&appointments).Error
+		})
+		if err != nil {
+			slog.Error("Failed to create appointments", "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save appointment"})
+			return
+		}
+		if len(conflicts) > 0 {
+			respondConflicts(c, conflicts)
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "Appointment scheduled successfully",
+			"data":    appointments,
+		})
+	}
+}
+
+// @Summary      Reschedule appointment
+// @Description  Change time, modality, location or notes of an open appointment
+// @Tags         Appointments
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string                          true  "Appointment ID"
+// @Param        input  body      domains.UpdateAppointmentInput  true  "Update Data"
+// @Success      200    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]string
+// @Failure      409    {object}  map[string]interface{}
+// @Router       /appointments/{id} [put]
+// @Security     Bearer
+func UpdateAppointmentHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		appointment, _, ok := loadAppointment(c)
+		if !ok {
+			return
+		}
+
+		var input domains.UpdateAppointmentInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if !appointment.Status.IsOpen() {
+			c.JSON(http.StatusConflict, gin.H{"error": "Only scheduled or confirmed appointments can be edited"})
+			return
+		}
+
+		rescheduled := input.StartsAt != nil || input.EndsAt != nil
+		if rescheduled {
+			if input.StartsAt != nil {
+				appointment.StartsAt = *input.StartsAt
+			}
+			if input.EndsAt != nil {
+				appointment.EndsAt = *input.EndsAt
+			}
+			if msg := validateSlot(appointment.StartsAt, appointment.EndsAt); msg != "" {
+				c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+				return
+			}
+		}
+
+		if input.Modality != "" {
+			appointment.Modality = domains.AppointmentModality(input.Modality)
+		}
+		if input.Location != "" {
+			appointment.Location = input.Location
+		}
+		if input.Notes != "" {
+			appointment.Notes = input.Notes
+		}
+
+		// Igual que al crear: verificación de conflictos y guardado con la agenda bloqueada
+		var conflicts []domains.Appointment
+		err := database.GetDB().Transaction(func(tx *gorm.DB) error {
+			if rescheduled {
+				if err := services.LockProfessionalSchedule(tx, appointment.ProfessionalID); err != nil {
+					return err
+				}
+				var err error
+				conflicts, err = services.FindAppointmentConflicts(tx, appointment.ProfessionalID,
+					[]services.TimeSlot{{Start: appointment.StartsAt, End: appointment.EndsAt}}, appointment.ID)
+				if err != nil || len(conflicts) > 0 {
+					return err
+				}
+			}
+			return tx.Save(&appointment).Error
+		})
+		if err != nil {
+			slog.Error("Failed to update appointment", "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update appointment"})
+			return
+		}
+		if len(conflicts) > 0 {
+			respondConflicts(c, conflicts)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Appointment updated successfully",
+			"data":    appointment,
+		})
+	}
+}
+
+// @Summary      Change appointment status
+// @Description  Confirm, cancel or mark as no-show. With apply_to_series, also applies to the following open appointments of the series. Completion happens by recording a session with appointment_id. Cancellations (which require cancelled_by) and no-shows are recorded as attendance events.
+// @Tags         Appointments
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string                                true  "Appointment ID"
+// @Param        input  body      domains.ChangeAppointmentStatusInput  true  "Status Data"
+// @Success      200    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]string
+// @Failure      409    {object}  map[string]string
+// @Router       /appointments/{id}/status [put]
+// @Security     Bearer
+func ChangeAppointmentStatusHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		appointment, currentUser, ok := loadAppointment(c)
+		if !ok {
+			return
+		}
+
+		var input domains.ChangeAppointmentStatusInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if domains.AppointmentStatus(input.Status) == domains.AppointmentCancelled && input.CancelledBy == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "cancelled_by is required when cancelling (FAMILY or PROFESSIONAL)"})
+			return
+		}
+
+		if appointment.Status == domains.AppointmentCompleted {
+			c.JSON(http.StatusConflict, gin.H{"error": "Completed appointments cannot change status"})
+			return
+		}
+
+		newStatus := domains.AppointmentStatus(input.Status)
+		db := database.GetDB()
+
+		targets := []domains.Appointment{appointment}
+		if input.ApplyToSeries && appointment.SeriesID != nil {
+			err := db.Where("id = ? OR (series_id = ? AND starts_at > ? AND status IN ?)",
+				appointment.ID, appointment.SeriesID, appointment.StartsAt,
+				[]domains.AppointmentStatus{domains.AppointmentScheduled, domains.AppointmentConfirmed}).
+				Order("starts_at ASC").
+				Find(&targets).Error
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch series appointments"})
+				return
+			}
+		}
+
+		var conflicts []domains.Appointment
+		err := db.Transaction(func(tx *gorm.DB) error {
+			// Reabrir una cita cancelada vuelve a ocupar la agenda: se verifica con la agenda bloqueada
+			if newStatus.IsOpen() {
+				var slots []services.TimeSlot
+				ids := make([]uuid.UUID, 0, len(targets))
+				for _, t := range targets {
+					ids = append(ids, t.ID)
+					if !t.Status.IsOpen() {
+						slots = append(slots, services.TimeSlot{Start: t.StartsAt, End: t.EndsAt})
+					}
+				}
+				if len(slots) > 0 {
+					if err := services.LockProfessionalSchedule(tx, appointment.ProfessionalID); err != nil {
+						return err
+					}
+					var err error
+					conflicts, err = services.FindAppointmentConflicts(tx, appointment.ProfessionalID, slots, ids...)
+					if err != nil || len(conflicts) > 0 {
+						return err
+					}
+				}
+			}
+
+			for i := range targets {
+				targets[i].Status = newStatus
+				targets[i].StatusReason = input.Reason
+				if err := tx.Save(&targets[i]).Error; err != nil {
+					return err
+				}
+				err := services.RecordAppointmentAttendance(tx, targets[i], input.AttendanceStatus(), input.Reason, currentUser.ID, nil)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update appointment status"})
+			return
+		}
+		if len(conflicts) > 0 {
+			respondConflicts(c, conflicts)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Appointment status updated",
+			"data":    targets,
+		})
+	}
+}
+
+// @Summary      Prefill session from appointment
+// @Description  Returns a CreateSessionInput prefilled from the appointment and the patient's last session. Posting it to /sessions completes the appointment.
+// @Tags         Appointments
+// @Produce      json
+// @Param        id   path      string  true  "Appointment ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]string
+// @Router       /appointments/{id}/session-input [get]
+// @Security     Bearer
+func GetAppointmentSessionInputHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		appointment, _, ok := loadAppointment(c)
+		if !ok {
+			return
+		}
+
+		if !appointment.Status.IsOpen() {
+			c.JSON(http.StatusConflict, gin.H{"error": "Appointment is " + string(appointment.Status)})
+			return
+		}
+
+		input := domains.CreateSessionInput{
+			PatientID:     appointment.PatientID.String(),
+			AppointmentID: appointment.ID.String(),
+		}
+
+		// El plan de intervención y las notas para esta sesión vienen de la sesión anterior
+		var lastSession domains.Session
+		err := database.GetDB().
+			Where("patient_id = ?", appointment.PatientID).
+			Order("created_at DESC").
+			First(&lastSession).Error
+		if err == nil {
+			input.InterventionPlan = lastSession.InterventionPlan
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"data": gin.H{
+				"session_input":          input,
+				"appointment":            appointment,
+				"previous_session_notes": lastSession.NextSessionNotes,
+			},
+		})
+	}
+}
+
+// @Summary      Download appointment as .ics
+// @Description  Download a single appointment as an iCalendar file. Patient names are reduced to initials unless full_names=true.
+// @Tags         Appointments
+// @Produce      text/calendar
+// @Param        id          path      string   true   "Appointment ID"
+// @Param        full_names  query     boolean  false  "Include the patient's full name"
+// @Success      200         {string}  string
+// @Failure      404         {object}  map[string]string
+// @Router       /appointments/{id}/ics [get]
+// @Security     Bearer
+func DownloadAppointmentICSHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		appointment, _, ok := loadAppointment(c)
+		if !ok {
+			return
+		}
+
+		database.GetDB().First(&appointment.Patient, "id = ?", appointment.PatientID)
+
+		event := services.AppointmentICSEvent(appointment, c.Query("full_names") == "true")
+		content := services.BuildICS("MedLog - Cita", []services.ICSEvent{event})
+
+		c.Header("Content-Disposition", "attachment; filename=cita-"+appointment.ID.String()+".ics")
+		c.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(content))
+	}
+}
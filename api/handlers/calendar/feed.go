@@ -0,0 +1,67 @@
+package calendar
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"bitacora-medica-backend/api/database"
+	"bitacora-medica-backend/api/domains"
+	"bitacora-medica-backend/api/services"
+	"bitacora-medica-backend/api/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	feedPastWindow   = 30 * 24 * time.Hour
+	feedFutureWindow = 180 * 24 * time.Hour
+)
+
+// CalendarFeedHandler sirve el feed iCalendar sin JWT: el token secreto de la URL
+// identifica al profesional. Los clientes de calendario no envían headers de auth.
+func CalendarFeedHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		plain := strings.TrimSuffix(c.Param("token"), ".ics")
+		if plain == "" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Calendar feed not found"})
+			return
+		}
+
+		db := database.GetDB()
+		var token domains.CalendarFeedToken
+		if err := db.First(&token, "token_hash = ?", utils.HashToken(plain)).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Calendar feed not found"})
+			return
+		}
+
+		var user domains.User
+		if err := db.First(&user, "id = ?", token.UserID).Error; err != nil || user.Status != domains.StatusActive {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Calendar feed not found"})
+			return
+		}
+
+		now := time.Now()
+		var appointments []domains.Appointment
+		if err := db.Preload("Patient").
+			Where("professional_id = ? AND starts_at >= ? AND starts_at < ?",
+				user.ID, now.Add(-feedPastWindow), now.Add(feedFutureWindow)).
+			Order("starts_at ASC").
+			Find(&appointments).Error; err != nil {
+			slog.Error("Failed to build calendar feed", "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build calendar feed"})
+			return
+		}
+
+		events := make([]services.ICSEvent, 0, len(appointments))
+		for _, a := range appointments {
+			events = append(events, services.AppointmentICSEvent(a, token.ShowFullNames))
+		}
+
+		db.Model(&token).UpdateColumn("last_used_at", now)
+
+		c.Header("Cache-Control", "private, max-age=300")
+		c.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(services.BuildICS("MedLog - Agenda", events)))
+	}
+}
@@ -0,0 +1,129 @@
+package calendar
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"bitacora-medica-backend/api/config"
+	"bitacora-medica-backend/api/database"
+	"bitacora-medica-backend/api/domains"
+	"bitacora-medica-backend/api/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// feedURL arma la URL pública del feed. Sin PUBLIC_API_URL se usa el host de la petición.
+func feedURL(c *gin.Context, cfg *config.Config, token string) string {
+	base := strings.TrimRight(cfg.PublicAPIURL, "/")
+	if base == "" {
+		scheme := "http"
+		if c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https" {
+			scheme = "https"
+		}
+		base = scheme + "://" + c.Request.Host
+	}
+	return base + "/calendar/feed/" + token + ".ics"
+}
+
+// @Summary      Calendar feed status
+// @Description  Tell whether the user has an active iCalendar feed token (the token itself is never returned again)
+// @Tags         Calendar
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Router       /calendar/token [get]
+// @Security     Bearer
+func GetCalendarTokenHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+
+		var token domains.CalendarFeedToken
+		if err := database.GetDB().First(&token, "user_id = ?", currentUser.ID).Error; err != nil {
+			c.JSON(http.StatusOK, gin.H{"data": gin.H{"active": false}})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"data": gin.H{
+				"active":          true,
+				"show_full_names": token.ShowFullNames,
+				"created_at":      token.CreatedAt,
+				"last_used_at":    token.LastUsedAt,
+			},
+		})
+	}
+}
+
+// @Summary      Regenerate calendar feed token
+// @Description  Create a new secret iCalendar feed URL. The previous URL stops working. The URL is only shown once.
+// @Tags         Calendar
+// @Accept       json
+// @Produce      json
+// @Param        input  body      domains.GenerateCalendarTokenInput  false  "Feed options"
+// @Success      201    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]string
+// @Router       /calendar/token [post]
+// @Security     Bearer
+func RegenerateCalendarTokenHandler(cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+
+		var input domains.GenerateCalendarTokenInput
+		if c.Request.ContentLength > 0 {
+			if err := c.ShouldBindJSON(&input); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+		}
+
+		plain, err := utils.GenerateSecureToken()
+		if err != nil {
+			slog.Error("Failed to generate calendar token", "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+			return
+		}
+
+		db := database.GetDB()
+		var token domains.CalendarFeedToken
+		db.Where("user_id = ?", currentUser.ID).Limit(1).Find(&token)
+
+		token.UserID = currentUser.ID
+		token.TokenHash = utils.HashToken(plain)
+		token.ShowFullNames = input.ShowFullNames
+		token.LastUsedAt = nil
+
+		if err := db.Save(&token).Error; err != nil {
+			slog.Error("Failed to save calendar token", "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "Calendar feed token generated. Store the URL now, it will not be shown again.",
+			"data": gin.H{
+				"feed_url":        feedURL(c, cfg, plain),
+				"show_full_names": token.ShowFullNames,
+			},
+		})
+	}
+}
+
+// @Summary      Revoke calendar feed token
+// @Description  Disable the user's iCalendar feed URL
+// @Tags         Calendar
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Router       /calendar/token [delete]
+// @Security     Bearer
+func RevokeCalendarTokenHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+
+		if err := database.GetDB().Where("user_id = ?", currentUser.ID).Delete(&domains.CalendarFeedToken{}).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke token"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Calendar feed disabled"})
+	}
+}
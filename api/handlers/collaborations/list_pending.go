@@ -5,15 +5,27 @@ import (
 
 	"bitacora-medica-backend/api/database"
 	"bitacora-medica-backend/api/domains"
+	"bitacora-medica-backend/api/pagination"
 
 	"github.com/gin-gonic/gin"
 )
 
+var invitationsPagination = pagination.Options{
+	Sorts: map[string]pagination.SortField{
+		"invited_at": {Column: "invited_at", Field: "InvitedAt", IsTime: true},
+	},
+	DefaultSort:  "invited_at",
+	DefaultOrder: pagination.Desc,
+}
+
 // GetPendingInvitationsHandler lista las invitaciones donde soy el profesional invitado
 // @Summary      List pending invitations
 // @Description  Get list of pending collaboration invitations for the current user
 // @Tags         Collaborations
 // @Produce      json
+// @Param        order   query     string  false  "asc or desc (default)"
+// @Param        limit   query     int     false  "Page size (max 100)"
+// @Param        cursor  query     string  false  "Cursor from the previous page"
 // @Success      200  {object}  map[string]interface{}
 // @Failure      500  {object}  map[string]string
 // @Router       /collaborations/pending [get]
@@ -22,16 +34,23 @@ func GetPendingInvitationsHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		currentUser := c.MustGet("currentUser").(domains.User)
 
+		params, err := pagination.Parse(c, invitationsPagination)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
 		var invitations []domains.Collaboration
 
-		if err := database.GetDB().
+		query := database.GetDB().
 			Preload("Patient").
-			Where("professional_id = ? AND status = ?", currentUser.ID, domains.CollabPending).
-			Find(&invitations).Error; err != nil {
+			Where("professional_id = ? AND status = ?", currentUser.ID, domains.CollabPending)
+		meta, err := params.Find(query, &invitations)
+		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch invitations"})
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{"data": invitations})
+		c.JSON(http.StatusOK, gin.H{"data": invitations, "meta": meta})
 	}
 }
@@ -2,6 +2,7 @@ package collaborations
 
 import (
 	"net/http"
+	"time"
 
 	"bitacora-medica-backend/api/config"
 	"bitacora-medica-backend/api/database"
@@ -61,6 +62,10 @@ func RespondInvitationHandler(cfg *config.Config) gin.HandlerFunc {
 
 		newStatus := domains.CollabStatus(input.Status)
 		collab.Status = newStatus
+		if newStatus == domains.CollabAccepted {
+			now := time.Now()
+			collab.AcceptedAt = &now
+		}
 
 		if err := db.Save(&collab).Error; err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update invitation status"})
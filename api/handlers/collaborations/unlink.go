@@ -4,6 +4,7 @@ import (
 	"bitacora-medica-backend/api/database"
 	"bitacora-medica-backend/api/domains"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -31,7 +32,9 @@ func UnlinkProfessionalHandler() gin.HandlerFunc {
 			return
 		}
 
+		now := time.Now()
 		collab.Status = domains.CollabRevoked
+		collab.RevokedAt = &now
 		if err := db.Save(&collab).Error; err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unlink professional"})
 			return
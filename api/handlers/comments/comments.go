@@ -0,0 +1,397 @@
+package comments
+
+import (
+	"net/http"
+	"time"
+
+	"bitacora-medica-backend/api/config"
+	"bitacora-medica-backend/api/database"
+	"bitacora-medica-backend/api/domains"
+	"bitacora-medica-backend/api/pagination"
+	"bitacora-medica-backend/api/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var threadsPagination = pagination.Options{
+	Sorts: map[string]pagination.SortField{
+		"created_at": {Column: "created_at", Field: "CreatedAt", IsTime: true},
+	},
+	DefaultSort:  "created_at",
+	DefaultOrder: pagination.Desc,
+}
+
+// commentTarget es el paciente (y la sesión, si corresponde) sobre el que se comenta.
+type commentTarget struct {
+	PatientID uuid.UUID
+	SessionID *uuid.UUID
+}
+
+// patientTarget resuelve el paciente de :id si el usuario forma parte de su equipo.
+func patientTarget(c *gin.Context, currentUser domains.User) (commentTarget, bool) {
+	patientID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid patient ID"})
+		return commentTarget{}, false
+	}
+	if !services.CanAccessPatient(database.GetDB(), currentUser, patientID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not part of this patient's care team"})
+		return commentTarget{}, false
+	}
+	return commentTarget{PatientID: patientID}, true
+}
+
+// sessionTarget resuelve la sesión de :id si el usuario forma parte del equipo de su paciente.
+func sessionTarget(c *gin.Context, currentUser domains.User) (commentTarget, bool) {
+	db := database.GetDB()
+
+	var session domains.Session
+	if err := db.Select("id", "patient_id").First(&session, "id = ?", c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		return commentTarget{}, false
+	}
+	if !services.CanAccessPatient(db, currentUser, session.PatientID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not part of this patient's care team"})
+		return commentTarget{}, false
+	}
+	return commentTarget{PatientID: session.PatientID, SessionID: &session.ID}, true
+}
+
+// listThreads responde los hilos del destino con sus respuestas en orden cronológico.
+func listThreads(c *gin.Context, target commentTarget) {
+	params, err := pagination.Parse(c, threadsPagination)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Un comentario raíz borrado sigue apareciendo (sin texto) mientras tenga respuestas
+	query := services.CommentThreadScope(database.GetDB().Unscoped(), target.PatientID, target.SessionID).
+		Where("parent_id IS NULL").
+		Where("deleted_at IS NULL OR EXISTS (SELECT 1 FROM comments r WHERE r.parent_id = comments.id AND r.deleted_at IS NULL)").
+		Preload("Author").
+		Preload("Replies", func(db *gorm.DB) *gorm.DB {
+			return db.Where("deleted_at IS NULL").Order("created_at ASC")
+		}).
+		Preload("Replies.Author")
+
+	var threads []domains.Comment
+	meta, err := params.Find(query, &threads)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch comments"})
+		return
+	}
+	for i := range threads {
+		if threads[i].DeletedAt.Valid {
+			threads[i].Body = ""
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": threads, "meta": meta})
+}
+
+// createComment guarda el comentario (o respuesta) y avisa a los demás participantes.
+func createComment(c *gin.Context, cfg *config.Config, currentUser domains.User, target commentTarget) {
+	var input domains.CreateCommentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	db := database.GetDB()
+
+	comment := domains.Comment{
+		PatientID: target.PatientID,
+		SessionID: target.SessionID,
+		AuthorID:  currentUser.ID,
+		Body:      input.Body,
+	}
+
+	if input.ParentID != "" {
+		var parent domains.Comment
+		if err := services.CommentThreadScope(db, target.PatientID, target.SessionID).
+			First(&parent, "id = ?", input.ParentID).Error; err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Parent comment not found in this thread"})
+			return
+		}
+		// Las respuestas a una respuesta se cuelgan del comentario raíz
+		rootID := parent.ID
+		if parent.ParentID != nil {
+			rootID = *parent.ParentID
+		}
+		comment.ParentID = &rootID
+	}
+
+	var mentions []domains.Mention
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&comment).Error; err != nil {
+			return err
+		}
+		var err error
+		mentions, err = services.RecordCommentMentions(tx, comment)
+		return err
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save comment"})
+		return
+	}
+	comment.Author = currentUser
+
+	notifier := services.NewNotificationService(cfg)
+	if len(mentions) > 0 {
+		go notifier.NotifyMentions(mentions, currentUser)
+	}
+
+	// Quien fue mencionado ya recibe el aviso de la mención
+	mentioned := make(map[uuid.UUID]bool)
+	for _, m := range mentions {
+		mentioned[m.MentionedUserID] = true
+	}
+	var recipients []uuid.UUID
+	for _, id := range services.CommentParticipants(db, comment) {
+		if !mentioned[id] {
+			recipients = append(recipients, id)
+		}
+	}
+	if len(recipients) > 0 {
+		go notifier.NotifyNewComment(comment, currentUser, recipients)
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Comment posted", "data": comment})
+}
+
+// @Summary      List patient comments
+// @Description  List the care team's comment threads about a patient, with replies
+// @Tags         Comments
+// @Produce      json
+// @Param        id      path      string  true   "Patient ID"
+// @Param        order   query     string  false  "asc or desc (default)"
+// @Param        limit   query     int     false  "Page size (max 100)"
+// @Param        cursor  query     string  false  "Cursor from the previous page"
+// @Success      200     {object}  map[string]interface{}
+// @Failure      403     {object}  map[string]string
+// @Router       /patients/{id}/comments [get]
+// @Security     Bearer
+func ListPatientCommentsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+		target, ok := patientTarget(c, currentUser)
+		if !ok {
+			return
+		}
+		listThreads(c, target)
+	}
+}
+
+// @Summary      Comment on patient
+// @Description  Start a thread about a patient or reply to one. Other participants are notified.
+// @Tags         Comments
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string                      true  "Patient ID"
+// @Param        input  body      domains.CreateCommentInput  true  "Comment"
+// @Success      201    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]string
+// @Failure      403    {object}  map[string]string
+// @Router       /patients/{id}/comments [post]
+// @Security     Bearer
+func CreatePatientCommentHandler(cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+		target, ok := patientTarget(c, currentUser)
+		if !ok {
+			return
+		}
+		createComment(c, cfg, currentUser, target)
+	}
+}
+
+// @Summary      List session comments
+// @Description  List the care team's comment threads about a session, with replies
+// @Tags         Comments
+// @Produce      json
+// @Param        id      path      string  true   "Session ID"
+// @Param        order   query     string  false  "asc or desc (default)"
+// @Param        limit   query     int     false  "Page size (max 100)"
+// @Param        cursor  query     string  false  "Cursor from the previous page"
+// @Success      200     {object}  map[string]interface{}
+// @Failure      403     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
+// @Router       /sessions/{id}/comments [get]
+// @Security     Bearer
+func ListSessionCommentsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+		target, ok := sessionTarget(c, currentUser)
+		if !ok {
+			return
+		}
+		listThreads(c, target)
+	}
+}
+
+// @Summary      Comment on session
+// @Description  Start a thread about a session or reply to one. The session author and other participants are notified.
+// @Tags         Comments
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string                      true  "Session ID"
+// @Param        input  body      domains.CreateCommentInput  true  "Comment"
+// @Success      201    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]string
+// @Failure      403    {object}  map[string]string
+// @Failure      404    {object}  map[string]string
+// @Router       /sessions/{id}/comments [post]
+// @Security     Bearer
+func CreateSessionCommentHandler(cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+		target, ok := sessionTarget(c, currentUser)
+		if !ok {
+			return
+		}
+		createComment(c, cfg, currentUser, target)
+	}
+}
+
+// loadComment carga el comentario de :id si el usuario forma parte del equipo del paciente.
+func loadComment(c *gin.Context, currentUser domains.User) (domains.Comment, bool) {
+	db := database.GetDB()
+
+	var comment domains.Comment
+	if err := db.First(&comment, "id = ?", c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Comment not found"})
+		return comment, false
+	}
+	if !services.CanAccessPatient(db, currentUser, comment.PatientID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Comment not found"})
+		return comment, false
+	}
+	return comment, true
+}
+
+// @Summary      Edit comment
+// @Description  Edit a comment (author only). The previous text is kept in the edit history.
+// @Tags         Comments
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string                      true  "Comment ID"
+// @Param        input  body      domains.UpdateCommentInput  true  "New text"
+// @Success      200    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]string
+// @Failure      403    {object}  map[string]string
+// @Failure      404    {object}  map[string]string
+// @Router       /comments/{id} [put]
+// @Security     Bearer
+func UpdateCommentHandler(cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+
+		var input domains.UpdateCommentInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		comment, ok := loadComment(c, currentUser)
+		if !ok {
+			return
+		}
+		if comment.AuthorID != currentUser.ID {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You can only edit your own comments"})
+			return
+		}
+		if comment.Body == input.Body {
+			c.JSON(http.StatusOK, gin.H{"message": "Comment unchanged", "data": comment})
+			return
+		}
+
+		var mentions []domains.Mention
+		err := database.GetDB().Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(&domains.CommentRevision{CommentID: comment.ID, Body: comment.Body}).Error; err != nil {
+				return err
+			}
+			now := time.Now()
+			comment.Body = input.Body
+			comment.EditedAt = &now
+			if err := tx.Save(&comment).Error; err != nil {
+				return err
+			}
+			var err error
+			mentions, err = services.RecordCommentMentions(tx, comment)
+			return err
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update comment"})
+			return
+		}
+
+		if len(mentions) > 0 {
+			go services.NewNotificationService(cfg).NotifyMentions(mentions, currentUser)
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Comment updated", "data": comment})
+	}
+}
+
+// @Summary      Comment edit history
+// @Description  List previous versions of a comment, oldest first
+// @Tags         Comments
+// @Produce      json
+// @Param        id   path      string  true  "Comment ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]string
+// @Router       /comments/{id}/history [get]
+// @Security     Bearer
+func GetCommentHistoryHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+
+		comment, ok := loadComment(c, currentUser)
+		if !ok {
+			return
+		}
+
+		var revisions []domains.CommentRevision
+		if err := database.GetDB().Where("comment_id = ?", comment.ID).Order("created_at ASC").Find(&revisions).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch comment history"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"data": revisions, "current": comment})
+	}
+}
+
+// @Summary      Delete comment
+// @Description  Delete a comment (author or admin). Replies are kept: a deleted first comment is listed without its text while its thread has replies.
+// @Tags         Comments
+// @Produce      json
+// @Param        id   path      string  true  "Comment ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Router       /comments/{id} [delete]
+// @Security     Bearer
+func DeleteCommentHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+
+		comment, ok := loadComment(c, currentUser)
+		if !ok {
+			return
+		}
+		if comment.AuthorID != currentUser.ID && currentUser.Role != domains.RoleAdmin {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You can only delete your own comments"})
+			return
+		}
+
+		if err := database.GetDB().Delete(&comment).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete comment"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Comment deleted"})
+	}
+}
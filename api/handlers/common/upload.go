@@ -2,13 +2,58 @@ package common
 
 import (
 	"net/http"
+	"strings"
 
 	"bitacora-medica-backend/api/config"
+	"bitacora-medica-backend/api/database"
+	"bitacora-medica-backend/api/domains"
 	"bitacora-medica-backend/api/services"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
+// uploadClientID lee el client_id opcional de las subidas offline. Si ya se subió
+// un archivo de ese paciente con ese client_id, devuelve su ruta para no subirlo de
+// nuevo; si el client_id se usó para otra cosa, responde 409.
+func uploadClientID(c *gin.Context, patientID string) (*uuid.UUID, string, bool) {
+	raw := c.PostForm("client_id")
+	if raw == "" {
+		return nil, "", true
+	}
+
+	clientID, err := uuid.Parse(raw)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid client_id"})
+		return nil, "", false
+	}
+
+	currentUser := c.MustGet("currentUser").(domains.User)
+	if receipt, found := services.FindSyncReceipt(database.GetDB(), currentUser.ID, clientID); found {
+		if receipt.Kind != domains.SyncKindUpload || !strings.HasPrefix(receipt.Path, patientID+"/") {
+			c.JSON(http.StatusConflict, gin.H{"error": "client_id was already used for a different record"})
+			return nil, "", false
+		}
+		return &clientID, receipt.Path, true
+	}
+	return &clientID, "", true
+}
+
+// saveUploadReceipt recuerda la ruta subida para los reintentos del mismo client_id.
+func saveUploadReceipt(c *gin.Context, clientID *uuid.UUID, path string) {
+	if clientID == nil {
+		return
+	}
+	currentUser := c.MustGet("currentUser").(domains.User)
+	database.GetDB().Create(&domains.SyncReceipt{
+		ClientID: *clientID,
+		UserID:   currentUser.ID,
+		Kind:     domains.SyncKindUpload,
+		Path:     path,
+		Status:   domains.SyncCreated,
+	})
+}
+
 func UploadImageHandler(cfg *config.Config) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		file, err := c.FormFile("file")
@@ -23,12 +68,29 @@ func UploadImageHandler(cfg *config.Config) gin.HandlerFunc {
 			return
 		}
 
+		clientID, previousPath, ok := uploadClientID(c, patientID)
+		if !ok {
+			return
+		}
+
 		storage := services.NewStorageService(cfg)
+		if previousPath != "" {
+			signedURL, _ := storage.GetPublicURL("session-evidence", previousPath)
+			c.JSON(http.StatusOK, gin.H{
+				"message":    "Image already uploaded",
+				"path":       previousPath,
+				"signed_url": signedURL,
+				"url":        signedURL,
+			})
+			return
+		}
+
 		path, signedURL, err := storage.UploadImage(patientID, file)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to upload image", "details": err.Error()})
 			return
 		}
+		saveUploadReceipt(c, clientID, path)
 
 		c.JSON(http.StatusOK, gin.H{
 			"message":    "Image uploaded successfully",
@@ -68,3 +130,57 @@ func UploadConsentHandler(cfg *config.Config) gin.HandlerFunc {
 		})
 	}
 }
+
+// UploadDocumentFileHandler sube el archivo de un documento sin crear el registro,
+// que llega después en el lote de /sync (flujo offline).
+func UploadDocumentFileHandler(cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		file, err := c.FormFile("file")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "File is mandatory"})
+			return
+		}
+
+		patientID := c.PostForm("patient_id")
+		parsedPatientID, err := uuid.Parse(patientID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "patient_id is required"})
+			return
+		}
+
+		currentUser := c.MustGet("currentUser").(domains.User)
+		if !services.CanAccessPatient(database.GetDB(), currentUser, parsedPatientID) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You are not part of this patient's care team"})
+			return
+		}
+
+		clientID, previousPath, ok := uploadClientID(c, patientID)
+		if !ok {
+			return
+		}
+
+		storage := services.NewStorageService(cfg)
+		if previousPath != "" {
+			signedURL, _ := storage.GetPublicURL("patient-documents", previousPath)
+			c.JSON(http.StatusOK, gin.H{
+				"message":    "Document already uploaded",
+				"path":       previousPath,
+				"signed_url": signedURL,
+			})
+			return
+		}
+
+		path, signedURL, err := storage.UploadPatientDocument(patientID, file)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to upload document", "details": err.Error()})
+			return
+		}
+		saveUploadReceipt(c, clientID, path)
+
+		c.JSON(http.StatusOK, gin.H{
+			"message":    "Document uploaded successfully",
+			"path":       path,
+			"signed_url": signedURL,
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package diagnoses
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"bitacora-medica-backend/api/config"
+	"bitacora-medica-backend/api/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SearchDiagnosesHandler autocompleta códigos y descripciones del catálogo CIE-10
+// @Summary      Search CIE-10 catalog
+// @Description  Autocomplete CIE-10 / ICD-10 diagnoses by code prefix or description words
+// @Tags         Diagnoses
+// @Produce      json
+// @Param        q      query     string  true   "Code prefix or text"
+// @Param        limit  query     int     false  "Max results (default 20, max 50)"
+// @Success      200    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]string
+// @Failure      500    {object}  map[string]string
+// @Router       /diagnoses/search [get]
+// @Security     Bearer
+func SearchDiagnosesHandler(cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		term := c.Query("q")
+		if len(term) < 2 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Query must have at least 2 characters"})
+			return
+		}
+
+		limit := 20
+		if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 {
+			limit = min(l, 50)
+		}
+
+		catalog, err := services.GetDiagnosisCatalog(cfg)
+		if err != nil {
+			slog.Error("Failed to load CIE-10 catalog", "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Diagnosis catalog unavailable"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"data": catalog.Search(term, limit)})
+	}
+}
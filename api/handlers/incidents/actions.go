@@ -0,0 +1,161 @@
+package incidents
+
+import (
+	"net/http"
+	"time"
+
+	"bitacora-medica-backend/api/database"
+	"bitacora-medica-backend/api/domains"
+	"bitacora-medica-backend/api/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// parseDueDate interpreta una fecha YYYY-MM-DD; vacía devuelve nil.
+func parseDueDate(value string) (*time.Time, bool) {
+	if value == "" {
+		return nil, true
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, false
+	}
+	return &date, true
+}
+
+// @Summary      Add incident follow-up action
+// @Description  Assign a follow-up action to a member of the patient's care team
+// @Tags         Incidents
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string                             true  "Incident ID"
+// @Param        input  body      domains.CreateIncidentActionInput  true  "Action Data"
+// @Success      201    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]string
+// @Failure      404    {object}  map[string]string
+// @Router       /incidents/{id}/actions [post]
+// @Security     Bearer
+func CreateIncidentActionHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+
+		var input domains.CreateIncidentActionInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		incident, ok := loadIncident(c)
+		if !ok {
+			return
+		}
+
+		if incident.Status == domains.IncidentClosed {
+			c.JSON(http.StatusConflict, gin.H{"error": "Incident is closed"})
+			return
+		}
+
+		db := database.GetDB()
+
+		assigneeID := currentUser.ID
+		if input.AssigneeID != "" {
+			parsed, err := uuid.Parse(input.AssigneeID)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid assignee ID"})
+				return
+			}
+			assigneeID = parsed
+		}
+		if !services.IsCareTeamMember(db, assigneeID, incident.PatientID) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "The assignee must be part of the patient's care team"})
+			return
+		}
+
+		dueDate, ok := parseDueDate(input.DueDate)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid due_date format. Use YYYY-MM-DD"})
+			return
+		}
+
+		action := domains.IncidentAction{
+			IncidentID:  incident.ID,
+			Description: input.Description,
+			AssigneeID:  assigneeID,
+			DueDate:     dueDate,
+			CreatedByID: currentUser.ID,
+		}
+
+		if err := db.Create(&action).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create action"})
+			return
+		}
+		db.First(&action.Assignee, "id = ?", action.AssigneeID)
+
+		c.JSON(http.StatusCreated, gin.H{"message": "Action created", "data": action})
+	}
+}
+
+// @Summary      Update incident follow-up action
+// @Description  Edit a follow-up action or mark it as completed
+// @Tags         Incidents
+// @Accept       json
+// @Produce      json
+// @Param        id         path      string                             true  "Incident ID"
+// @Param        action_id  path      string                             true  "Action ID"
+// @Param        input      body      domains.UpdateIncidentActionInput  true  "Action Data"
+// @Success      200        {object}  map[string]interface{}
+// @Failure      400        {object}  map[string]string
+// @Failure      404        {object}  map[string]string
+// @Router       /incidents/{id}/actions/{action_id} [put]
+// @Security     Bearer
+func UpdateIncidentActionHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input domains.UpdateIncidentActionInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		incident, ok := loadIncident(c)
+		if !ok {
+			return
+		}
+
+		db := database.GetDB()
+
+		var action domains.IncidentAction
+		if err := db.First(&action, "id = ? AND incident_id = ?", c.Param("action_id"), incident.ID).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Action not found"})
+			return
+		}
+
+		if input.Description != "" {
+			action.Description = input.Description
+		}
+		if input.DueDate != "" {
+			dueDate, ok := parseDueDate(input.DueDate)
+			if !ok {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid due_date format. Use YYYY-MM-DD"})
+				return
+			}
+			action.DueDate = dueDate
+		}
+		if input.Completed != nil {
+			if *input.Completed && action.CompletedAt == nil {
+				now := time.Now()
+				action.CompletedAt = &now
+			} else if !*input.Completed {
+				action.CompletedAt = nil
+			}
+		}
+
+		if err := db.Omit("Assignee").Save(&action).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update action"})
+			return
+		}
+		db.First(&action.Assignee, "id = ?", action.AssigneeID)
+
+		c.JSON(http.StatusOK, gin.H{"message": "Action updated", "data": action})
+	}
+}
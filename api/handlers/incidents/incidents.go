@@ -0,0 +1,276 @@
+package incidents
+
+import (
+	"net/http"
+	"time"
+
+	"bitacora-medica-backend/api/database"
+	"bitacora-medica-backend/api/domains"
+	"bitacora-medica-backend/api/pagination"
+	"bitacora-medica-backend/api/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+var incidentsPagination = pagination.Options{
+	Sorts: map[string]pagination.SortField{
+		"created_at": {Column: "created_at", Field: "CreatedAt", IsTime: true},
+	},
+	DefaultSort:  "created_at",
+	DefaultOrder: pagination.Desc,
+}
+
+// loadIncident carga el incidente de :id si el usuario tiene acceso a su paciente.
+func loadIncident(c *gin.Context) (domains.Incident, bool) {
+	currentUser := c.MustGet("currentUser").(domains.User)
+
+	var incident domains.Incident
+	err := services.VisibleIncidents(database.GetDB(), currentUser).
+		Preload("ReportedBy").
+		Preload("AcknowledgedBy").
+		First(&incident, "id = ?", c.Param("id")).Error
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Incident not found"})
+		return incident, false
+	}
+	return incident, true
+}
+
+// @Summary      List incidents
+// @Description  List incidents of accessible patients, newest first
+// @Tags         Incidents
+// @Produce      json
+// @Param        status      query     string  false  "OPEN, ACKNOWLEDGED, UNDER_REVIEW or CLOSED"
+// @Param        severity    query     string  false  "LOW, MODERATE, HIGH or CRITICAL"
+// @Param        category    query     string  false  "Incident category"
+// @Param        patient_id  query     string  false  "Filter by patient"
+// @Param        limit       query     int     false  "Page size"
+// @Param        cursor      query     string  false  "Cursor from the previous page"
+// @Success      200         {object}  map[string]interface{}
+// @Failure      400         {object}  map[string]string
+// @Router       /incidents [get]
+// @Security     Bearer
+func ListIncidentsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+
+		params, err := pagination.Parse(c, incidentsPagination)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		query := services.VisibleIncidents(database.GetDB(), currentUser).Preload("ReportedBy")
+		if status := c.Query("status"); status != "" {
+			query = query.Where("status = ?", status)
+		}
+		if severity := c.Query("severity"); severity != "" {
+			query = query.Where("severity = ?", severity)
+		}
+		if category := c.Query("category"); category != "" {
+			query = query.Where("category = ?", category)
+		}
+		if patientID := c.Query("patient_id"); patientID != "" {
+			if _, err := uuid.Parse(patientID); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid patient ID"})
+				return
+			}
+			query = query.Where("patient_id = ?", patientID)
+		}
+
+		var incidents []domains.Incident
+		meta, err := params.Find(query, &incidents)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch incidents"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"data": incidents, "meta": meta})
+	}
+}
+
+// @Summary      Get incident
+// @Description  Get an incident with its follow-up actions and escalation history
+// @Tags         Incidents
+// @Produce      json
+// @Param        id   path      string  true  "Incident ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]string
+// @Router       /incidents/{id} [get]
+// @Security     Bearer
+func GetIncidentHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		incident, ok := loadIncident(c)
+		if !ok {
+			return
+		}
+
+		db := database.GetDB()
+		db.Preload("Assignee").Order("created_at ASC").Find(&incident.Actions, "incident_id = ?", incident.ID)
+		db.Order("level ASC").Find(&incident.Escalations, "incident_id = ?", incident.ID)
+
+		c.JSON(http.StatusOK, gin.H{"data": incident})
+	}
+}
+
+// @Summary      Acknowledge incident
+// @Description  Mark an open incident as acknowledged by the current user
+// @Tags         Incidents
+// @Produce      json
+// @Param        id   path      string  true  "Incident ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Router       /incidents/{id}/acknowledge [post]
+// @Security     Bearer
+func AcknowledgeIncidentHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+
+		incident, ok := loadIncident(c)
+		if !ok {
+			return
+		}
+
+		if incident.Status != domains.IncidentOpen {
+			c.JSON(http.StatusConflict, gin.H{"error": "Incident is already " + string(incident.Status)})
+			return
+		}
+
+		acknowledge(&incident, currentUser)
+		if err := database.GetDB().Omit("ReportedBy", "AcknowledgedBy", "Actions").Save(&incident).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to acknowledge incident"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Incident acknowledged", "data": incident})
+	}
+}
+
+// acknowledge registra quién tomó conocimiento del incidente.
+func acknowledge(incident *domains.Incident, user domains.User) {
+	now := time.Now()
+	incident.Status = domains.IncidentAcknowledged
+	incident.AcknowledgedByID = &user.ID
+	incident.AcknowledgedAt = &now
+	incident.AcknowledgedBy = &user
+}
+
+// @Summary      Change incident status
+// @Description  Move an incident through its workflow. Closing requires a root cause.
+// @Tags         Incidents
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string                             true  "Incident ID"
+// @Param        input  body      domains.ChangeIncidentStatusInput  true  "New status"
+// @Success      200    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]string
+// @Failure      404    {object}  map[string]string
+// @Failure      409    {object}  map[string]string
+// @Router       /incidents/{id}/status [put]
+// @Security     Bearer
+func ChangeIncidentStatusHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+
+		var input domains.ChangeIncidentStatusInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		incident, ok := loadIncident(c)
+		if !ok {
+			return
+		}
+
+		newStatus := domains.IncidentStatus(input.Status)
+		if incident.Status == newStatus {
+			c.JSON(http.StatusConflict, gin.H{"error": "Incident is already " + string(incident.Status)})
+			return
+		}
+
+		if input.RootCause != "" {
+			incident.RootCause = input.RootCause
+		}
+
+		switch newStatus {
+		case domains.IncidentAcknowledged:
+			if incident.Status != domains.IncidentOpen {
+				c.JSON(http.StatusConflict, gin.H{"error": "Only open incidents can be acknowledged"})
+				return
+			}
+			acknowledge(&incident, currentUser)
+		case domains.IncidentUnderReview:
+			// Revisar implica haber tomado conocimiento; se registra si se saltó el paso
+			if incident.AcknowledgedByID == nil {
+				acknowledge(&incident, currentUser)
+			}
+			incident.Status = domains.IncidentUnderReview
+			incident.ClosedAt = nil
+		case domains.IncidentClosed:
+			if incident.RootCause == "" {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "root_cause is required to close an incident"})
+				return
+			}
+			if incident.AcknowledgedByID == nil {
+				acknowledge(&incident, currentUser)
+			}
+			now := time.Now()
+			incident.Status = domains.IncidentClosed
+			incident.ClosedAt = &now
+		}
+
+		if err := database.GetDB().Omit("ReportedBy", "AcknowledgedBy", "Actions").Save(&incident).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update incident status"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Incident status updated", "data": incident})
+	}
+}
+
+// @Summary      Update incident
+// @Description  Update the severity, category or root-cause notes of an incident
+// @Tags         Incidents
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string                       true  "Incident ID"
+// @Param        input  body      domains.UpdateIncidentInput  true  "Incident Data"
+// @Success      200    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]string
+// @Failure      404    {object}  map[string]string
+// @Router       /incidents/{id} [put]
+// @Security     Bearer
+func UpdateIncidentHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input domains.UpdateIncidentInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		incident, ok := loadIncident(c)
+		if !ok {
+			return
+		}
+
+		if input.Severity != "" {
+			incident.Severity = domains.Severity(input.Severity)
+		}
+		if input.Category != "" {
+			incident.Category = domains.IncidentCategory(input.Category)
+		}
+		if input.RootCause != "" {
+			incident.RootCause = input.RootCause
+		}
+
+		if err := database.GetDB().Omit("ReportedBy", "AcknowledgedBy", "Actions").Save(&incident).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update incident"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Incident updated", "data": incident})
+	}
+}
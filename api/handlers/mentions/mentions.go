@@ -0,0 +1,108 @@
+package mentions
+
+import (
+	"net/http"
+	"time"
+
+	"bitacora-medica-backend/api/database"
+	"bitacora-medica-backend/api/domains"
+	"bitacora-medica-backend/api/pagination"
+	"bitacora-medica-backend/api/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+var mentionsPagination = pagination.Options{
+	Sorts: map[string]pagination.SortField{
+		"created_at": {Column: "created_at", Field: "CreatedAt", IsTime: true},
+	},
+	DefaultSort:  "created_at",
+	DefaultOrder: pagination.Desc,
+}
+
+// @Summary      List my mentions
+// @Description  List the current user's mentions in sessions and comments. Unresolved only by default.
+// @Tags         Mentions
+// @Produce      json
+// @Param        status  query     string  false  "open (default), resolved or all"
+// @Param        limit   query     int     false  "Page size (max 100)"
+// @Param        cursor  query     string  false  "Cursor from the previous page"
+// @Success      200     {object}  map[string]interface{}
+// @Failure      400     {object}  map[string]string
+// @Router       /mentions [get]
+// @Security     Bearer
+func ListMentionsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+
+		params, err := pagination.Parse(c, mentionsPagination)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		db := database.GetDB()
+		// Solo de pacientes a los que el usuario aún tiene acceso y de textos que siguen vigentes
+		query := db.Model(&domains.Mention{}).
+			Preload("Author").
+			Where("mentioned_user_id = ?", currentUser.ID).
+			Where("session_id IS NULL OR session_id IN (?)", db.Model(&domains.Session{}).Select("id")).
+			Where("comment_id IS NULL OR comment_id IN (?)", db.Model(&domains.Comment{}).Select("id"))
+		if currentUser.Role != domains.RoleAdmin {
+			query = query.Where("patient_id IN (?)", services.AccessiblePatientIDs(db, currentUser.ID))
+		}
+
+		switch c.DefaultQuery("status", "open") {
+		case "open":
+			query = query.Where("resolved_at IS NULL")
+		case "resolved":
+			query = query.Where("resolved_at IS NOT NULL")
+		case "all":
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status. Options: open, resolved, all"})
+			return
+		}
+
+		var mentions []domains.Mention
+		meta, err := params.Find(query, &mentions)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch mentions"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"data": mentions, "meta": meta})
+	}
+}
+
+// @Summary      Resolve mention
+// @Description  Mark one of the current user's mentions as resolved
+// @Tags         Mentions
+// @Produce      json
+// @Param        id   path      string  true  "Mention ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]string
+// @Router       /mentions/{id}/resolve [post]
+// @Security     Bearer
+func ResolveMentionHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+		db := database.GetDB()
+
+		var mention domains.Mention
+		if err := db.First(&mention, "id = ? AND mentioned_user_id = ?", c.Param("id"), currentUser.ID).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Mention not found"})
+			return
+		}
+
+		if mention.ResolvedAt == nil {
+			now := time.Now()
+			mention.ResolvedAt = &now
+			if err := db.Model(&mention).Update("resolved_at", now).Error; err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to resolve mention"})
+				return
+			}
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Mention resolved", "data": mention})
+	}
+}
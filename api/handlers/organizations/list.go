@@ -0,0 +1,33 @@
+package organizations
+
+import (
+	"net/http"
+
+	"bitacora-medica-backend/api/database"
+	"bitacora-medica-backend/api/domains"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary      List my organizations
+// @Description  List the organizations the user belongs to, with their role
+// @Tags         Organizations
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Router       /organizations [get]
+// @Security     Bearer
+func ListMyOrganizationsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+
+		var memberships []domains.OrganizationMember
+		if err := database.GetDB().Preload("Organization").
+			Where("user_id = ?", currentUser.ID).
+			Find(&memberships).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch organizations"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"data": memberships})
+	}
+}
@@ -0,0 +1,31 @@
+package patients
+
+import (
+	"net/http"
+
+	"bitacora-medica-backend/api/database"
+	"bitacora-medica-backend/api/domains"
+	"bitacora-medica-backend/api/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// authorizePatient valida el ID de la ruta y que el usuario pertenezca al equipo
+// del paciente. Si falla, ya respondió al cliente.
+func authorizePatient(c *gin.Context) (uuid.UUID, domains.User, bool) {
+	currentUser := c.MustGet("currentUser").(domains.User)
+
+	patientID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid patient ID"})
+		return uuid.Nil, currentUser, false
+	}
+
+	if !services.CanAccessPatient(database.GetDB(), currentUser, patientID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not part of this patient's care team"})
+		return uuid.Nil, currentUser, false
+	}
+
+	return patientID, currentUser, true
+}
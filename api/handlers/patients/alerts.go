@@ -0,0 +1,154 @@
+package patients
+
+import (
+	"net/http"
+
+	"bitacora-medica-backend/api/database"
+	"bitacora-medica-backend/api/domains"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary      List patient alerts
+// @Description  List clinical alerts (allergies, contraindications, seizure risk, behavioral...) ordered by severity
+// @Tags         Patients
+// @Produce      json
+// @Param        id      path      string  true   "Patient ID"
+// @Param        active  query     boolean false  "Only active alerts"
+// @Success      200     {object}  map[string]interface{}
+// @Failure      403     {object}  map[string]string
+// @Router       /patients/{id}/alerts [get]
+// @Security     Bearer
+func ListAlertsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		patientID, _, ok := authorizePatient(c)
+		if !ok {
+			return
+		}
+
+		query := database.GetDB().Preload("CreatedBy").Where("patient_id = ?", patientID)
+		if c.Query("active") == "true" {
+			query = query.Where("is_active = ?", true)
+		}
+
+		var alerts []domains.PatientAlert
+		if err := query.Order("is_active DESC").
+			Order(domains.SeverityOrderSQL).
+			Order("created_at DESC").
+			Find(&alerts).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch alerts"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"data": alerts})
+	}
+}
+
+// @Summary      Add patient alert
+// @Description  Register an allergy, contraindication or other clinical alert
+// @Tags         Patients
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string                    true  "Patient ID"
+// @Param        input  body      domains.CreateAlertInput  true  "Alert Data"
+// @Success      201    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]string
+// @Failure      403    {object}  map[string]string
+// @Failure      500    {object}  map[string]string
+// @Router       /patients/{id}/alerts [post]
+// @Security     Bearer
+func CreateAlertHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		patientID, currentUser, ok := authorizePatient(c)
+		if !ok {
+			return
+		}
+
+		var input domains.CreateAlertInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		alert := domains.PatientAlert{
+			PatientID:   patientID,
+			Type:        domains.AlertType(input.Type),
+			Severity:    domains.Severity(input.Severity),
+			Title:       input.Title,
+			Description: input.Description,
+			IsActive:    true,
+			CreatedByID: currentUser.ID,
+		}
+
+		if err := database.GetDB().Create(&alert).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save alert"})
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "Alert created successfully",
+			"data":    alert,
+		})
+	}
+}
+
+// @Summary      Update patient alert
+// @Description  Edit an alert or deactivate it with is_active=false
+// @Tags         Patients
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string                    true  "Patient ID"
+// @Param        alert_id  path      string                    true  "Alert ID"
+// @Param        input     body      domains.UpdateAlertInput  true  "Update Data"
+// @Success      200       {object}  map[string]interface{}
+// @Failure      400       {object}  map[string]string
+// @Failure      404       {object}  map[string]string
+// @Router       /patients/{id}/alerts/{alert_id} [put]
+// @Security     Bearer
+func UpdateAlertHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		patientID, _, ok := authorizePatient(c)
+		if !ok {
+			return
+		}
+
+		var input domains.UpdateAlertInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		db := database.GetDB()
+		var alert domains.PatientAlert
+		if err := db.First(&alert, "id = ? AND patient_id = ?", c.Param("alert_id"), patientID).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Alert not found"})
+			return
+		}
+
+		if input.Type != "" {
+			alert.Type = domains.AlertType(input.Type)
+		}
+		if input.Severity != "" {
+			alert.Severity = domains.Severity(input.Severity)
+		}
+		if input.Title != "" {
+			alert.Title = input.Title
+		}
+		if input.Description != "" {
+			alert.Description = input.Description
+		}
+		if input.IsActive != nil {
+			alert.IsActive = *input.IsActive
+		}
+
+		if err := db.Save(&alert).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update alert"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Alert updated successfully",
+			"data":    alert,
+		})
+	}
+}
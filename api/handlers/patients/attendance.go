@@ -0,0 +1,164 @@
+package patients
+
+import (
+	"net/http"
+	"time"
+
+	"bitacora-medica-backend/api/database"
+	"bitacora-medica-backend/api/domains"
+	"bitacora-medica-backend/api/pagination"
+	"bitacora-medica-backend/api/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var attendancePagination = pagination.Options{
+	Sorts: map[string]pagination.SortField{
+		"occurred_at": {Column: "occurred_at", Field: "OccurredAt", IsTime: true},
+	},
+	DefaultSort:  "occurred_at",
+	DefaultOrder: pagination.Desc,
+}
+
+// @Summary      List patient attendance
+// @Description  Attendance events (attended, cancellations, no-shows) of a patient with aggregated statistics over the whole date range
+// @Tags         Patients
+// @Produce      json
+// @Param        id      path      string  true   "Patient ID"
+// @Param        from    query     string  false  "From date (YYYY-MM-DD)"
+// @Param        to      query     string  false  "To date (YYYY-MM-DD)"
+// @Param        limit   query     int     false  "Page size"
+// @Param        cursor  query     string  false  "Cursor from the previous page"
+// @Success      200     {object}  map[string]interface{}
+// @Failure      400     {object}  map[string]string
+// @Failure      403     {object}  map[string]string
+// @Router       /patients/{id}/attendance [get]
+// @Security     Bearer
+func ListAttendanceHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		patientID, _, ok := authorizePatient(c)
+		if !ok {
+			return
+		}
+
+		params, err := pagination.Parse(c, attendancePagination)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		var from, to *time.Time
+		if value := c.Query("from"); value != "" {
+			parsed, err := time.Parse("2006-01-02", value)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from date format (YYYY-MM-DD)"})
+				return
+			}
+			from = &parsed
+		}
+		if value := c.Query("to"); value != "" {
+			parsed, err := time.Parse("2006-01-02", value)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to date format (YYYY-MM-DD)"})
+				return
+			}
+			end := parsed.AddDate(0, 0, 1)
+			to = &end
+		}
+
+		db := database.GetDB()
+		scope := func() *gorm.DB {
+			query := db.Model(&domains.AttendanceRecord{}).Where("patient_id = ?", patientID)
+			if from != nil {
+				query = query.Where("occurred_at >= ?", *from)
+			}
+			if to != nil {
+				query = query.Where("occurred_at < ?", *to)
+			}
+			return query
+		}
+
+		var records []domains.AttendanceRecord
+		meta, err := params.Find(scope().Preload("Professional").Preload("RecordedBy"), &records)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch attendance"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"data": records,
+			"meta": meta,
+			"stats": gin.H{
+				"overall":         services.SummarizeAttendance(scope()),
+				"by_professional": services.AttendanceByProfessional(db, scope()),
+			},
+		})
+	}
+}
+
+// @Summary      Record patient attendance
+// @Description  Record an attendance event not tied to an appointment (e.g. a cancellation by phone)
+// @Tags         Patients
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string                         true  "Patient ID"
+// @Param        input  body      domains.CreateAttendanceInput  true  "Attendance Data"
+// @Success      201    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]string
+// @Failure      403    {object}  map[string]string
+// @Router       /patients/{id}/attendance [post]
+// @Security     Bearer
+func CreateAttendanceHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		patientID, currentUser, ok := authorizePatient(c)
+		if !ok {
+			return
+		}
+
+		var input domains.CreateAttendanceInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		db := database.GetDB()
+		record := domains.AttendanceRecord{
+			PatientID:      patientID,
+			ProfessionalID: currentUser.ID,
+			Status:         domains.AttendanceStatus(input.Status),
+			Reason:         input.Reason,
+			OccurredAt:     time.Now(),
+			RecordedByID:   currentUser.ID,
+		}
+		if input.OccurredAt != nil {
+			record.OccurredAt = *input.OccurredAt
+		}
+
+		if input.ProfessionalID != "" {
+			professionalID, err := uuid.Parse(input.ProfessionalID)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid professional ID"})
+				return
+			}
+			var professional domains.User
+			if err := db.First(&professional, "id = ?", professionalID).Error; err != nil ||
+				!services.CanAccessPatient(db, professional, patientID) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "The professional must be part of the patient's care team"})
+				return
+			}
+			record.ProfessionalID = professionalID
+		}
+
+		if err := db.Create(&record).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save attendance"})
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "Attendance recorded",
+			"data":    record,
+		})
+	}
+}
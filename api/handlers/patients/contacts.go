@@ -0,0 +1,219 @@
+package patients
+
+import (
+	"net/http"
+
+	"bitacora-medica-backend/api/database"
+	"bitacora-medica-backend/api/domains"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// validateContact aplica las reglas que no se pueden expresar con binding.
+func validateContact(contact domains.PatientContact) string {
+	if contact.NotifyIncidents && contact.Email == "" {
+		return "An email is required to notify incidents to a contact"
+	}
+	if contact.NotifyIncidents && !contact.IsLegalGuardian {
+		return "Only legal guardians can receive incident notifications"
+	}
+	return ""
+}
+
+// newContact arma un contacto a partir del input de creación.
+func newContact(patientID uuid.UUID, input domains.CreateContactInput) domains.PatientContact {
+	contact := domains.PatientContact{
+		PatientID:           patientID,
+		Name:                input.Name,
+		Relationship:        input.Relationship,
+		Phones:              input.Phones,
+		Email:               input.Email,
+		IsLegalGuardian:     input.IsLegalGuardian,
+		HasConsentAuthority: input.HasConsentAuthority,
+		ContactOrder:        input.ContactOrder,
+		NotifyIncidents:     input.NotifyIncidents,
+	}
+	if contact.ContactOrder == 0 {
+		contact.ContactOrder = 1
+	}
+	return contact
+}
+
+// @Summary      List patient contacts
+// @Description  List emergency contacts and guardians of a patient in preferred contact order
+// @Tags         Patients
+// @Produce      json
+// @Param        id   path      string  true  "Patient ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]string
+// @Router       /patients/{id}/contacts [get]
+// @Security     Bearer
+func ListContactsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		patientID, _, ok := authorizePatient(c)
+		if !ok {
+			return
+		}
+
+		var contacts []domains.PatientContact
+		if err := database.GetDB().
+			Where("patient_id = ?", patientID).
+			Order("contact_order ASC, created_at ASC").
+			Find(&contacts).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch contacts"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"data": contacts})
+	}
+}
+
+// @Summary      Add patient contact
+// @Description  Add an emergency contact or guardian to a patient
+// @Tags         Patients
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string                      true  "Patient ID"
+// @Param        input  body      domains.CreateContactInput  true  "Contact Data"
+// @Success      201    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]string
+// @Failure      403    {object}  map[string]string
+// @Router       /patients/{id}/contacts [post]
+// @Security     Bearer
+func CreateContactHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		patientID, _, ok := authorizePatient(c)
+		if !ok {
+			return
+		}
+
+		var input domains.CreateContactInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		contact := newContact(patientID, input)
+		if msg := validateContact(contact); msg != "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+			return
+		}
+
+		if err := database.GetDB().Create(&contact).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save contact"})
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "Contact added successfully",
+			"data":    contact,
+		})
+	}
+}
+
+// @Summary      Update patient contact
+// @Tags         Patients
+// @Accept       json
+// @Produce      json
+// @Param        id          path      string                      true  "Patient ID"
+// @Param        contact_id  path      string                      true  "Contact ID"
+// @Param        input       body      domains.UpdateContactInput  true  "Update Data"
+// @Success      200         {object}  map[string]interface{}
+// @Failure      400         {object}  map[string]string
+// @Failure      404         {object}  map[string]string
+// @Router       /patients/{id}/contacts/{contact_id} [put]
+// @Security     Bearer
+func UpdateContactHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		patientID, _, ok := authorizePatient(c)
+		if !ok {
+			return
+		}
+
+		var input domains.UpdateContactInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		db := database.GetDB()
+		var contact domains.PatientContact
+		if err := db.First(&contact, "id = ? AND patient_id = ?", c.Param("contact_id"), patientID).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Contact not found"})
+			return
+		}
+
+		if input.Name != "" {
+			contact.Name = input.Name
+		}
+		if input.Relationship != "" {
+			contact.Relationship = input.Relationship
+		}
+		if len(input.Phones) > 0 {
+			contact.Phones = input.Phones
+		}
+		if input.Email != "" {
+			contact.Email = input.Email
+		}
+		if input.IsLegalGuardian != nil {
+			contact.IsLegalGuardian = *input.IsLegalGuardian
+		}
+		if input.HasConsentAuthority != nil {
+			contact.HasConsentAuthority = *input.HasConsentAuthority
+		}
+		if input.ContactOrder != 0 {
+			contact.ContactOrder = input.ContactOrder
+		}
+		if input.NotifyIncidents != nil {
+			contact.NotifyIncidents = *input.NotifyIncidents
+		}
+
+		if msg := validateContact(contact); msg != "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+			return
+		}
+
+		if err := db.Save(&contact).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update contact"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Contact updated successfully",
+			"data":    contact,
+		})
+	}
+}
+
+// @Summary      Delete patient contact
+// @Tags         Patients
+// @Produce      json
+// @Param        id          path      string  true  "Patient ID"
+// @Param        contact_id  path      string  true  "Contact ID"
+// @Success      200         {object}  map[string]interface{}
+// @Failure      404         {object}  map[string]string
+// @Router       /patients/{id}/contacts/{contact_id} [delete]
+// @Security     Bearer
+func DeleteContactHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		patientID, _, ok := authorizePatient(c)
+		if !ok {
+			return
+		}
+
+		result := database.GetDB().
+			Where("id = ? AND patient_id = ?", c.Param("contact_id"), patientID).
+			Delete(&domains.PatientContact{})
+		if result.Error != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete contact"})
+			return
+		}
+		if result.RowsAffected == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Contact not found"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Contact deleted successfully"})
+	}
+}
@@ -12,20 +12,33 @@ import (
 )
 
 type AIContextResponse struct {
-	Patient     PatientSummary      `json:"patient"`
-	Team        []ContextTeamMember `json:"team"`
-	FullHistory []SessionDetailed   `json:"full_history_sessions"`
-	Reports     []ReportSummary     `json:"reports"`
-	ConsentInfo string              `json:"consent_info"`
+	ActiveAlerts       []AlertSummary      `json:"active_alerts"`
+	Patient            PatientSummary      `json:"patient"`
+	CurrentMedications []MedicationSummary `json:"current_medications"`
+	Team               []ContextTeamMember `json:"team"`
+	FullHistory        []SessionDetailed   `json:"full_history_sessions"`
+	Reports            []ReportSummary     `json:"reports"`
+	ConsentInfo        string              `json:"consent_info"`
 }
 
 type PatientSummary struct {
-	ID               string      `json:"id"`
-	Name             string      `json:"name,omitempty"`
-	PersonalInfo     interface{} `json:"personal_info"`
-	DisabilityReport string      `json:"disability_report"`
-	CareNotes        string      `json:"care_notes"`
-	IncidentCount    int64       `json:"incident_count"`
+	ID               string             `json:"id"`
+	Name             string             `json:"name,omitempty"`
+	PersonalInfo     interface{}        `json:"personal_info"`
+	DisabilityReport string             `json:"disability_report"`
+	CareNotes        string             `json:"care_notes"`
+	IncidentCount    int64              `json:"incident_count"`
+	Diagnoses        []DiagnosisSummary `json:"diagnoses"`
+}
+
+type DiagnosisSummary struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+	Group       string `json:"group"`
+	Type        string `json:"type"`
+	Status      string `json:"status"`
+	OnsetDate   string `json:"onset_date,omitempty"`
+	DiagnosedBy string `json:"diagnosed_by"`
 }
 
 type SessionDetailed struct {
@@ -39,6 +52,31 @@ type SessionDetailed struct {
 	HasIncident        bool                   `json:"has_incident"`
 	IncidentDetails    string                 `json:"incident_details,omitempty"`
 	NextSessionNotes   string                 `json:"next_session_notes,omitempty"`
+	Medications        []string               `json:"medications,omitempty"`
+	PendingCosign      bool                   `json:"pending_cosign,omitempty"` // Sesión de un supervisado aún sin co-firma
+	GroupSession       *GroupSessionSummary   `json:"group_session,omitempty"`  // Solo en sesiones grupales
+}
+
+type GroupSessionSummary struct {
+	Title        string `json:"title,omitempty"`
+	Participants int64  `json:"participants"`
+}
+
+type AlertSummary struct {
+	Type        string `json:"type"`
+	Severity    string `json:"severity"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+}
+
+type MedicationSummary struct {
+	DrugName   string `json:"drug_name"`
+	Dose       string `json:"dose"`
+	Route      string `json:"route"`
+	Frequency  string `json:"frequency"`
+	Prescriber string `json:"prescriber,omitempty"`
+	StartDate  string `json:"start_date"`
+	EndDate    string `json:"end_date,omitempty"`
 }
 
 type ContextTeamMember struct {
@@ -58,7 +96,7 @@ type ReportSummary struct {
 
 // GetPatientAIContextHandler devuelve todo el contexto disponible del paciente
 // @Summary      Get FULL patient context for AI
-// @Description  Get comprehensive patient data (profile, team, history, reports) for RAG
+// @Description  Get comprehensive patient data (active alerts first, then profile, team, history, reports) for RAG
 // @Tags         Patients
 // @Produce      json
 // @Param        id   path      string  true  "Patient ID"
@@ -117,6 +155,38 @@ func GetPatientAIContextHandler() gin.HandlerFunc {
 			Order("created_at desc").
 			Find(&sessions)
 
+		// Las sesiones grupales comparten descripción y plan con otros pacientes
+		groupSummaries := make(map[string]*GroupSessionSummary)
+		var groupRows []struct {
+			ID           string
+			Title        string
+			Participants int64
+		}
+		db.Table("group_sessions").
+			Select("group_sessions.id, group_sessions.title, count(sessions.id) as participants").
+			Joins("JOIN sessions ON sessions.group_session_id = group_sessions.id AND sessions.deleted_at IS NULL").
+			Where("group_sessions.id IN (?)", db.Model(&domains.Session{}).
+				Select("group_session_id").
+				Where("patient_id = ? AND group_session_id IS NOT NULL", patientID)).
+			Group("group_sessions.id, group_sessions.title").
+			Scan(&groupRows)
+		for _, g := range groupRows {
+			groupSummaries[g.ID] = &GroupSessionSummary{Title: g.Title, Participants: g.Participants}
+		}
+
+		var medicationEvents []domains.SessionMedicationEvent
+		db.Preload("Medication").
+			Where("patient_id = ?", patientID).
+			Order("created_at ASC").
+			Find(&medicationEvents)
+
+		eventsBySession := make(map[string][]string)
+		for _, e := range medicationEvents {
+			key := e.SessionID.String()
+			eventsBySession[key] = append(eventsBySession[key],
+				string(e.Action)+": "+e.Medication.DrugName+" "+e.Dose)
+		}
+
 		var sessionHistory []SessionDetailed
 		for _, s := range sessions {
 			profName := s.Creator.Email
@@ -133,6 +203,11 @@ func GetPatientAIContextHandler() gin.HandlerFunc {
 				_ = json.Unmarshal(s.Vitals, &vitals)
 			}
 
+			var group *GroupSessionSummary
+			if s.GroupSessionID != nil {
+				group = groupSummaries[s.GroupSessionID.String()]
+			}
+
 			sessionHistory = append(sessionHistory, SessionDetailed{
 				Date:               s.CreatedAt,
 				ProfessionalName:   profName,
@@ -144,6 +219,9 @@ func GetPatientAIContextHandler() gin.HandlerFunc {
 				HasIncident:        s.HasIncident,
 				IncidentDetails:    s.IncidentDetails,
 				NextSessionNotes:   s.NextSessionNotes,
+				Medications:        eventsBySession[s.ID.String()],
+				PendingCosign:      s.CosignStatus.AwaitingCosign(),
+				GroupSession:       group,
 			})
 		}
 
@@ -168,18 +246,79 @@ func GetPatientAIContextHandler() gin.HandlerFunc {
 		var incidentCount int64
 		db.Model(&domains.Session{}).Where("patient_id = ? AND has_incident = ?", patientID, true).Count(&incidentCount)
 
+		var diagnoses []domains.PatientDiagnosis
+		db.Preload("DiagnosedBy").
+			Where("patient_id = ? AND status <> ?", patientID, domains.DiagnosisRuledOut).
+			Order("type ASC, created_at DESC").
+			Find(&diagnoses)
+
+		var diagnosisSummaries []DiagnosisSummary
+		for _, d := range diagnoses {
+			summary := DiagnosisSummary{
+				Code:        d.Code,
+				Description: d.Description,
+				Group:       d.GroupName,
+				Type:        string(d.Type),
+				Status:      string(d.Status),
+				DiagnosedBy: d.DiagnosedBy.Email,
+			}
+			if d.OnsetDate != nil {
+				summary.OnsetDate = d.OnsetDate.Format("2006-01-02")
+			}
+			diagnosisSummaries = append(diagnosisSummaries, summary)
+		}
+
+		var medications []domains.PatientMedication
+		db.Where("patient_id = ? AND status = ?", patientID, domains.MedicationActive).
+			Order("start_date DESC").
+			Find(&medications)
+
+		var medicationSummaries []MedicationSummary
+		for _, m := range medications {
+			summary := MedicationSummary{
+				DrugName:   m.DrugName,
+				Dose:       m.Dose,
+				Route:      string(m.Route),
+				Frequency:  m.Frequency,
+				Prescriber: m.PrescriberName,
+				StartDate:  m.StartDate.Format("2006-01-02"),
+			}
+			if m.EndDate != nil {
+				summary.EndDate = m.EndDate.Format("2006-01-02")
+			}
+			medicationSummaries = append(medicationSummaries, summary)
+		}
+
+		var alerts []domains.PatientAlert
+		db.Where("patient_id = ? AND is_active = ?", patientID, true).
+			Order(domains.SeverityOrderSQL).
+			Find(&alerts)
+
+		var alertSummaries []AlertSummary
+		for _, a := range alerts {
+			alertSummaries = append(alertSummaries, AlertSummary{
+				Type:        string(a.Type),
+				Severity:    string(a.Severity),
+				Title:       a.Title,
+				Description: a.Description,
+			})
+		}
+
 		response := AIContextResponse{
+			ActiveAlerts: alertSummaries,
 			Patient: PatientSummary{
 				ID:               patient.ID.String(),
 				PersonalInfo:     patient.PersonalInfo,
 				DisabilityReport: patient.DisabilityReport,
 				CareNotes:        patient.CareNotes,
 				IncidentCount:    incidentCount,
+				Diagnoses:        diagnosisSummaries,
 			},
-			Team:        team,
-			FullHistory: sessionHistory,
-			Reports:     reportSummaries,
-			ConsentInfo: patient.ConsentPDFUrl,
+			CurrentMedications: medicationSummaries,
+			Team:               team,
+			FullHistory:        sessionHistory,
+			Reports:            reportSummaries,
+			ConsentInfo:        patient.ConsentPDFUrl,
 		}
 
 		c.JSON(http.StatusOK, response)
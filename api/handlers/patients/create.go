@@ -8,10 +8,13 @@ import (
 	"bitacora-medica-backend/api/config"
 	"bitacora-medica-backend/api/database"
 	"bitacora-medica-backend/api/domains"
+	"bitacora-medica-backend/api/services"
 	"bitacora-medica-backend/api/utils"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"gorm.io/datatypes"
+	"gorm.io/gorm"
 )
 
 // Input Validado
@@ -26,6 +29,8 @@ type CreatePatientInput struct {
 	ConsentPDFUrl  string `json:"consent_pdf_url"`
 	Sex            string `json:"sex" binding:"required"`
 	EmergencyPhone string `json:"emergency_phone"`
+
+	Contacts []domains.CreateContactInput `json:"contacts" binding:"omitempty,dive"`
 }
 
 func calculateAge(birthDateStr string) int {
@@ -42,6 +47,48 @@ func calculateAge(birthDateStr string) int {
 	return age
 }
 
+// validatePatientInput aplica las reglas que no cubre el binding (RUT y fecha de nacimiento).
+// Se comparte con la importación masiva.
+func validatePatientInput(input CreatePatientInput) string {
+	if !utils.ValidateRUT(input.RUT) {
+		return "Invalid RUT format or verification digit"
+	}
+
+	if _, err := time.Parse("2006-01-02", input.BirthDate); err != nil {
+		return "Birth date must be YYYY-MM-DD"
+	}
+
+	return ""
+}
+
+// newPatient arma el paciente con su PersonalInfo a partir de un input ya validado.
+func newPatient(creatorID uuid.UUID, input CreatePatientInput) (domains.Patient, error) {
+	personalInfoMap := map[string]interface{}{
+		"first_name":      input.FirstName,
+		"last_name":       input.LastName,
+		"rut":             input.RUT,
+		"birth_date":      input.BirthDate,
+		"email":           input.Email,
+		"phone":           input.Phone,
+		"diagnosis":       input.Diagnosis,
+		"sex":             input.Sex,
+		"age":             calculateAge(input.BirthDate),
+		"emergency_phone": input.EmergencyPhone,
+	}
+
+	personalInfoBytes, err := json.Marshal(personalInfoMap)
+	if err != nil {
+		return domains.Patient{}, err
+	}
+
+	return domains.Patient{
+		CreatorID:     creatorID,
+		PersonalInfo:  datatypes.JSON(personalInfoBytes),
+		ConsentPDFUrl: input.ConsentPDFUrl,
+		Status:        domains.PatientActive,
+	}, nil
+}
+
 // @Summary      Create a new patient
 // @Description  Create a new patient record
 // @Tags         Patients
@@ -50,6 +97,7 @@ func calculateAge(birthDateStr string) int {
 // @Param        input body CreatePatientInput true "Patient Creation Data"
 // @Success      201  {object}  map[string]interface{}
 // @Failure      400  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /patients [post]
 // @Security     Bearer
@@ -63,51 +111,52 @@ func CreatePatientHandler(cfg *config.Config) gin.HandlerFunc {
 			return
 		}
 
-		if !utils.ValidateRUT(input.RUT) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid RUT format or verification digit"})
-			return
-		}
-
-		if _, err := time.Parse("2006-01-02", input.BirthDate); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Birth date must be YYYY-MM-DD"})
+		if msg := validatePatientInput(input); msg != "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": msg})
 			return
 		}
 
-		age := calculateAge(input.BirthDate)
-
-		personalInfoMap := map[string]interface{}{
-			"first_name":      input.FirstName,
-			"last_name":       input.LastName,
-			"rut":             input.RUT,
-			"birth_date":      input.BirthDate,
-			"email":           input.Email,
-			"phone":           input.Phone,
-			"diagnosis":       input.Diagnosis,
-			"sex":             input.Sex,
-			"age":             age,
-			"emergency_phone": input.EmergencyPhone,
-		}
-
-		personalInfoBytes, err := json.Marshal(personalInfoMap)
+		patient, err := newPatient(currentUser.ID, input)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process personal info"})
 			return
 		}
 
-		patient := domains.Patient{
-			CreatorID:     currentUser.ID,
-			PersonalInfo:  datatypes.JSON(personalInfoBytes),
-			ConsentPDFUrl: input.ConsentPDFUrl,
+		contacts := make([]domains.PatientContact, 0, len(input.Contacts))
+		for _, in := range input.Contacts {
+			contact := newContact(uuid.Nil, in)
+			if msg := validateContact(contact); msg != "" {
+				c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+				return
+			}
+			contacts = append(contacts, contact)
 		}
 
-		if err := database.GetDB().Create(&patient).Error; err != nil {
+		err = database.GetDB().Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(&patient).Error; err != nil {
+				return err
+			}
+			for i := range contacts {
+				contacts[i].PatientID = patient.ID
+			}
+			if len(contacts) > 0 {
+				return tx.Create(&contacts).Error
+			}
+			return nil
+		})
+		if services.IsUniqueViolation(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": "This RUT cannot be registered. Contact an administrator if the patient already exists."})
+			return
+		}
+		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create patient"})
 			return
 		}
 
 		c.JSON(http.StatusCreated, gin.H{
-			"message": "Patient created successfully",
-			"data":    patient,
+			"message":  "Patient created successfully",
+			"data":     patient,
+			"contacts": contacts,
 		})
 	}
 }
@@ -0,0 +1,244 @@
+package patients
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"bitacora-medica-backend/api/config"
+	"bitacora-medica-backend/api/database"
+	"bitacora-medica-backend/api/domains"
+	"bitacora-medica-backend/api/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// demoteOtherPrimaries deja un solo diagnóstico principal activo por paciente.
+func demoteOtherPrimaries(tx *gorm.DB, patientID uuid.UUID, keepID uuid.UUID) error {
+	return tx.Model(&domains.PatientDiagnosis{}).
+		Where("patient_id = ? AND id <> ? AND type = ?", patientID, keepID, domains.DiagnosisPrimary).
+		Update("type", domains.DiagnosisSecondary).Error
+}
+
+// @Summary      List patient diagnoses
+// @Description  List structured CIE-10 diagnoses of a patient (primary first)
+// @Tags         Patients
+// @Produce      json
+// @Param        id   path      string  true  "Patient ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]string
+// @Router       /patients/{id}/diagnoses [get]
+// @Security     Bearer
+func ListDiagnosesHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		patientID, _, ok := authorizePatient(c)
+		if !ok {
+			return
+		}
+
+		var diagnoses []domains.PatientDiagnosis
+		if err := database.GetDB().Preload("DiagnosedBy").
+			Where("patient_id = ?", patientID).
+			Order("type ASC, created_at DESC").
+			Find(&diagnoses).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch diagnoses"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"data": diagnoses})
+	}
+}
+
+// @Summary      Add patient diagnosis
+// @Description  Attach a CIE-10 diagnosis to a patient. A new PRIMARY diagnosis demotes the previous one.
+// @Tags         Patients
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string                        true  "Patient ID"
+// @Param        input  body      domains.CreateDiagnosisInput  true  "Diagnosis Data"
+// @Success      201    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]string
+// @Failure      403    {object}  map[string]string
+// @Failure      500    {object}  map[string]string
+// @Router       /patients/{id}/diagnoses [post]
+// @Security     Bearer
+func AddDiagnosisHandler(cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		patientID, currentUser, ok := authorizePatient(c)
+		if !ok {
+			return
+		}
+
+		var input domains.CreateDiagnosisInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		catalog, err := services.GetDiagnosisCatalog(cfg)
+		if err != nil {
+			slog.Error("Failed to load CIE-10 catalog", "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Diagnosis catalog unavailable"})
+			return
+		}
+
+		entry, found := catalog.Lookup(input.Code)
+		if !found {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown CIE-10 code: " + input.Code})
+			return
+		}
+
+		diagnosis := domains.PatientDiagnosis{
+			PatientID:     patientID,
+			Code:          entry.Code,
+			Description:   entry.Description,
+			GroupCode:     entry.GroupCode,
+			GroupName:     entry.GroupName,
+			Type:          domains.DiagnosisSecondary,
+			Status:        domains.DiagnosisActive,
+			DiagnosedByID: currentUser.ID,
+			Notes:         input.Notes,
+		}
+		if input.Type != "" {
+			diagnosis.Type = domains.DiagnosisType(input.Type)
+		}
+		if input.Status != "" {
+			diagnosis.Status = domains.DiagnosisStatus(input.Status)
+		}
+		if input.OnsetDate != "" {
+			onset, err := time.Parse("2006-01-02", input.OnsetDate)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid onset date format (YYYY-MM-DD)"})
+				return
+			}
+			diagnosis.OnsetDate = &onset
+		}
+
+		err = database.GetDB().Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(&diagnosis).Error; err != nil {
+				return err
+			}
+			if diagnosis.Type == domains.DiagnosisPrimary {
+				return demoteOtherPrimaries(tx, patientID, diagnosis.ID)
+			}
+			return nil
+		})
+		if err != nil {
+			slog.Error("Failed to save diagnosis", "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save diagnosis"})
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "Diagnosis added successfully",
+			"data":    diagnosis,
+		})
+	}
+}
+
+// @Summary      Update patient diagnosis
+// @Description  Change type, status, onset date or notes of a patient diagnosis
+// @Tags         Patients
+// @Accept       json
+// @Produce      json
+// @Param        id            path      string                        true  "Patient ID"
+// @Param        diagnosis_id  path      string                        true  "Diagnosis ID"
+// @Param        input         body      domains.UpdateDiagnosisInput  true  "Update Data"
+// @Success      200           {object}  map[string]interface{}
+// @Failure      400           {object}  map[string]string
+// @Failure      404           {object}  map[string]string
+// @Router       /patients/{id}/diagnoses/{diagnosis_id} [put]
+// @Security     Bearer
+func UpdateDiagnosisHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		patientID, _, ok := authorizePatient(c)
+		if !ok {
+			return
+		}
+
+		var input domains.UpdateDiagnosisInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		db := database.GetDB()
+		var diagnosis domains.PatientDiagnosis
+		if err := db.First(&diagnosis, "id = ? AND patient_id = ?", c.Param("diagnosis_id"), patientID).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Diagnosis not found"})
+			return
+		}
+
+		if input.Type != "" {
+			diagnosis.Type = domains.DiagnosisType(input.Type)
+		}
+		if input.Status != "" {
+			diagnosis.Status = domains.DiagnosisStatus(input.Status)
+		}
+		if input.OnsetDate != "" {
+			onset, err := time.Parse("2006-01-02", input.OnsetDate)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid onset date format (YYYY-MM-DD)"})
+				return
+			}
+			diagnosis.OnsetDate = &onset
+		}
+		if input.Notes != "" {
+			diagnosis.Notes = input.Notes
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Save(&diagnosis).Error; err != nil {
+				return err
+			}
+			if diagnosis.Type == domains.DiagnosisPrimary {
+				return demoteOtherPrimaries(tx, patientID, diagnosis.ID)
+			}
+			return nil
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update diagnosis"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Diagnosis updated successfully",
+			"data":    diagnosis,
+		})
+	}
+}
+
+// @Summary      Delete patient diagnosis
+// @Description  Remove a diagnosis entered by mistake (use status RULED_OUT for clinical changes)
+// @Tags         Patients
+// @Produce      json
+// @Param        id            path      string  true  "Patient ID"
+// @Param        diagnosis_id  path      string  true  "Diagnosis ID"
+// @Success      200           {object}  map[string]interface{}
+// @Failure      404           {object}  map[string]string
+// @Router       /patients/{id}/diagnoses/{diagnosis_id} [delete]
+// @Security     Bearer
+func DeleteDiagnosisHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		patientID, _, ok := authorizePatient(c)
+		if !ok {
+			return
+		}
+
+		db := database.GetDB()
+		var diagnosis domains.PatientDiagnosis
+		if err := db.First(&diagnosis, "id = ? AND patient_id = ?", c.Param("diagnosis_id"), patientID).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Diagnosis not found"})
+			return
+		}
+
+		if err := db.Delete(&diagnosis).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete diagnosis"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Diagnosis deleted successfully"})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"bitacora-medica-backend/api/config"
 	"bitacora-medica-backend/api/database"
 	"bitacora-medica-backend/api/domains"
+	"bitacora-medica-backend/api/pagination"
 	"bitacora-medica-backend/api/services"
 
 	"github.com/gin-gonic/gin"
@@ -90,6 +91,15 @@ func UploadDocumentHandler(cfg *config.Config) gin.HandlerFunc {
 	}
 }
 
+var documentsPagination = pagination.Options{
+	Sorts: map[string]pagination.SortField{
+		"date":       {Column: "date", Field: "Date", IsTime: true},
+		"created_at": {Column: "created_at", Field: "CreatedAt", IsTime: true},
+	},
+	DefaultSort:  "date",
+	DefaultOrder: pagination.Desc,
+}
+
 func ListDocumentsHandler(cfg *config.Config) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		patientIDStr := c.Param("id")
@@ -98,10 +108,15 @@ func ListDocumentsHandler(cfg *config.Config) gin.HandlerFunc {
 			return
 		}
 
-		var docs []domains.PatientDocument
-		result := database.GetDB().Where("patient_id = ?", patientIDStr).Order("date desc").Find(&docs)
+		params, err := pagination.Parse(c, documentsPagination)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 
-		if result.Error != nil {
+		var docs []domains.PatientDocument
+		meta, err := params.Find(database.GetDB().Where("patient_id = ?", patientIDStr), &docs)
+		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list documents"})
 			return
 		}
@@ -119,7 +134,7 @@ func ListDocumentsHandler(cfg *config.Config) gin.HandlerFunc {
 
 		}
 
-		c.JSON(http.StatusOK, gin.H{"data": docs})
+		c.JSON(http.StatusOK, gin.H{"data": docs, "meta": meta})
 	}
 }
 
@@ -18,10 +18,14 @@ type TeamMember struct {
 }
 
 type PatientProfileResponse struct {
-	Patient        domains.Patient   `json:"patient"`
-	Team           []TeamMember      `json:"team"`
-	RecentSessions []domains.Session `json:"recent_sessions"`
-	IncidentCount  int64             `json:"incident_count"`
+	ActiveAlerts   []domains.PatientAlert      `json:"active_alerts"`
+	Patient        domains.Patient             `json:"patient"`
+	Team           []TeamMember                `json:"team"`
+	RecentSessions []domains.Session           `json:"recent_sessions"`
+	IncidentCount  int64                       `json:"incident_count"`
+	Diagnoses      []domains.PatientDiagnosis  `json:"diagnoses"`
+	Medications    []domains.PatientMedication `json:"medications"`
+	Contacts       []domains.PatientContact    `json:"contacts"`
 }
 
 // @Summary      Get patient profile
@@ -106,11 +110,36 @@ func GetPatientProfileHandler(cfg *config.Config) gin.HandlerFunc {
 		var incidentCount int64
 		db.Model(&domains.Session{}).Where("patient_id = ? AND has_incident = ?", id, true).Count(&incidentCount)
 
+		var diagnoses []domains.PatientDiagnosis
+		db.Where("patient_id = ? AND status <> ?", id, domains.DiagnosisRuledOut).
+			Order("type ASC, created_at DESC").
+			Find(&diagnoses)
+
+		var activeAlerts []domains.PatientAlert
+		db.Where("patient_id = ? AND is_active = ?", id, true).
+			Order(domains.SeverityOrderSQL).
+			Order("created_at DESC").
+			Find(&activeAlerts)
+
+		var medications []domains.PatientMedication
+		db.Where("patient_id = ? AND status = ?", id, domains.MedicationActive).
+			Order("start_date DESC").
+			Find(&medications)
+
+		var contacts []domains.PatientContact
+		db.Where("patient_id = ?", id).
+			Order("contact_order ASC, created_at ASC").
+			Find(&contacts)
+
 		response := PatientProfileResponse{
+			ActiveAlerts:   activeAlerts,
 			Patient:        patient,
 			Team:           collaborators,
 			RecentSessions: sessions,
 			IncidentCount:  incidentCount,
+			Diagnoses:      diagnoses,
+			Medications:    medications,
+			Contacts:       contacts,
 		}
 
 		c.JSON(http.StatusOK, gin.H{"data": response})
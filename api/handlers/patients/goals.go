@@ -0,0 +1,352 @@
+package patients
+
+import (
+	"net/http"
+	"time"
+
+	"bitacora-medica-backend/api/database"
+	"bitacora-medica-backend/api/domains"
+	"bitacora-medica-backend/api/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// resolveGoalOwner valida que el responsable del objetivo pertenezca al equipo del paciente.
+func resolveGoalOwner(c *gin.Context, patientID uuid.UUID, ownerIDStr string) (uuid.UUID, bool) {
+	ownerID, err := uuid.Parse(ownerIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid owner ID"})
+		return uuid.Nil, false
+	}
+
+	db := database.GetDB()
+	var owner domains.User
+	if err := db.First(&owner, "id = ?", ownerID).Error; err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Owner not found"})
+		return uuid.Nil, false
+	}
+
+	if !services.CanAccessPatient(db, owner, patientID) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "The owner must be part of the patient's care team"})
+		return uuid.Nil, false
+	}
+
+	return ownerID, true
+}
+
+// @Summary      List patient goals
+// @Description  List therapeutic goals of a patient with their latest progress score
+// @Tags         Patients
+// @Produce      json
+// @Param        id      path      string  true   "Patient ID"
+// @Param        status  query     string  false  "Filter by status"
+// @Success      200     {object}  map[string]interface{}
+// @Failure      403     {object}  map[string]string
+// @Router       /patients/{id}/goals [get]
+// @Security     Bearer
+func ListGoalsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		patientID, _, ok := authorizePatient(c)
+		if !ok {
+			return
+		}
+
+		db := database.GetDB()
+		query := db.Preload("Owner").Where("patient_id = ?", patientID)
+		if status := c.Query("status"); status != "" {
+			query = query.Where("status = ?", status)
+		}
+
+		var goals []domains.TherapeuticGoal
+		if err := query.Order("status ASC, due_date ASC NULLS LAST").Find(&goals).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch goals"})
+			return
+		}
+
+		type latestScore struct {
+			GoalID uuid.UUID
+			Score  int
+		}
+		var latest []latestScore
+		db.Raw(`
+			SELECT DISTINCT ON (goal_id) goal_id, score
+			FROM goal_progresses
+			WHERE patient_id = ? AND score IS NOT NULL
+			ORDER BY goal_id, created_at DESC
+		`, patientID).Scan(&latest)
+
+		scores := make(map[uuid.UUID]int)
+		for _, l := range latest {
+			scores[l.GoalID] = l.Score
+		}
+
+		type goalWithScore struct {
+			domains.TherapeuticGoal
+			LatestScore *int `json:"latest_score"`
+		}
+		response := make([]goalWithScore, 0, len(goals))
+		for _, g := range goals {
+			item := goalWithScore{TherapeuticGoal: g}
+			if score, found := scores[g.ID]; found {
+				item.LatestScore = &score
+			}
+			response = append(response, item)
+		}
+
+		c.JSON(http.StatusOK, gin.H{"data": response})
+	}
+}
+
+// @Summary      Create patient goal
+// @Description  Create a therapeutic goal with baseline, target, owner and due date
+// @Tags         Patients
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string                   true  "Patient ID"
+// @Param        input  body      domains.CreateGoalInput  true  "Goal Data"
+// @Success      201    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]string
+// @Failure      403    {object}  map[string]string
+// @Failure      500    {object}  map[string]string
+// @Router       /patients/{id}/goals [post]
+// @Security     Bearer
+func CreateGoalHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		patientID, currentUser, ok := authorizePatient(c)
+		if !ok {
+			return
+		}
+
+		var input domains.CreateGoalInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		goal := domains.TherapeuticGoal{
+			PatientID:   patientID,
+			Title:       input.Title,
+			Description: input.Description,
+			Baseline:    input.Baseline,
+			Target:      input.Target,
+			OwnerID:     currentUser.ID,
+			Status:      domains.GoalActive,
+		}
+
+		if input.OwnerID != "" {
+			ownerID, ok := resolveGoalOwner(c, patientID, input.OwnerID)
+			if !ok {
+				return
+			}
+			goal.OwnerID = ownerID
+		}
+
+		if input.DueDate != "" {
+			dueDate, err := time.Parse("2006-01-02", input.DueDate)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid due date format (YYYY-MM-DD)"})
+				return
+			}
+			goal.DueDate = &dueDate
+		}
+
+		if err := database.GetDB().Create(&goal).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save goal"})
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "Goal created successfully",
+			"data":    goal,
+		})
+	}
+}
+
+// @Summary      Update patient goal
+// @Description  Edit a goal or close it by changing its status
+// @Tags         Patients
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                   true  "Patient ID"
+// @Param        goal_id  path      string                   true  "Goal ID"
+// @Param        input    body      domains.UpdateGoalInput  true  "Update Data"
+// @Success      200      {object}  map[string]interface{}
+// @Failure      400      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Router       /patients/{id}/goals/{goal_id} [put]
+// @Security     Bearer
+func UpdateGoalHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		patientID, _, ok := authorizePatient(c)
+		if !ok {
+			return
+		}
+
+		var input domains.UpdateGoalInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		db := database.GetDB()
+		var goal domains.TherapeuticGoal
+		if err := db.First(&goal, "id = ? AND patient_id = ?", c.Param("goal_id"), patientID).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Goal not found"})
+			return
+		}
+
+		if input.Title != "" {
+			goal.Title = input.Title
+		}
+		if input.Description != "" {
+			goal.Description = input.Description
+		}
+		if input.Baseline != "" {
+			goal.Baseline = input.Baseline
+		}
+		if input.Target != "" {
+			goal.Target = input.Target
+		}
+		if input.OwnerID != "" {
+			ownerID, ok := resolveGoalOwner(c, patientID, input.OwnerID)
+			if !ok {
+				return
+			}
+			goal.OwnerID = ownerID
+		}
+		if input.DueDate != "" {
+			dueDate, err := time.Parse("2006-01-02", input.DueDate)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid due date format (YYYY-MM-DD)"})
+				return
+			}
+			goal.DueDate = &dueDate
+		}
+		if input.Status != "" && domains.GoalStatus(input.Status) != goal.Status {
+			goal.Status = domains.GoalStatus(input.Status)
+			if goal.Status == domains.GoalActive {
+				goal.ClosedAt = nil
+			} else {
+				now := time.Now()
+				goal.ClosedAt = &now
+			}
+		}
+
+		if err := db.Save(&goal).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update goal"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Goal updated successfully",
+			"data":    goal,
+		})
+	}
+}
+
+// @Summary      Goal progress timeline
+// @Description  Get the goal with every progress entry in chronological order
+// @Tags         Patients
+// @Produce      json
+// @Param        id       path      string  true  "Patient ID"
+// @Param        goal_id  path      string  true  "Goal ID"
+// @Success      200      {object}  map[string]interface{}
+// @Failure      404      {object}  map[string]string
+// @Router       /patients/{id}/goals/{goal_id}/progress [get]
+// @Security     Bearer
+func GetGoalProgressHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		patientID, _, ok := authorizePatient(c)
+		if !ok {
+			return
+		}
+
+		db := database.GetDB()
+		var goal domains.TherapeuticGoal
+		if err := db.Preload("Owner").First(&goal, "id = ? AND patient_id = ?", c.Param("goal_id"), patientID).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Goal not found"})
+			return
+		}
+
+		var timeline []domains.GoalProgress
+		if err := db.Preload("RecordedBy").
+			Where("goal_id = ?", goal.ID).
+			Order("created_at ASC").
+			Find(&timeline).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch goal progress"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"data": gin.H{
+				"goal":     goal,
+				"timeline": timeline,
+			},
+		})
+	}
+}
+
+// @Summary      Record goal progress
+// @Description  Record progress on a goal outside of a session
+// @Tags         Patients
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                           true  "Patient ID"
+// @Param        goal_id  path      string                           true  "Goal ID"
+// @Param        input    body      domains.RecordGoalProgressInput  true  "Progress Data"
+// @Success      201      {object}  map[string]interface{}
+// @Failure      400      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      409      {object}  map[string]string
+// @Router       /patients/{id}/goals/{goal_id}/progress [post]
+// @Security     Bearer
+func RecordGoalProgressHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		patientID, currentUser, ok := authorizePatient(c)
+		if !ok {
+			return
+		}
+
+		var input domains.RecordGoalProgressInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if input.Score == nil && input.Note == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Goal progress requires a score or a note"})
+			return
+		}
+
+		db := database.GetDB()
+		var goal domains.TherapeuticGoal
+		if err := db.First(&goal, "id = ? AND patient_id = ?", c.Param("goal_id"), patientID).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Goal not found"})
+			return
+		}
+
+		if goal.Status != domains.GoalActive {
+			c.JSON(http.StatusConflict, gin.H{"error": "Progress can only be recorded on active goals"})
+			return
+		}
+
+		entry := domains.GoalProgress{
+			GoalID:       goal.ID,
+			PatientID:    patientID,
+			RecordedByID: currentUser.ID,
+			Score:        input.Score,
+			Note:         input.Note,
+		}
+
+		if err := db.Create(&entry).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save goal progress"})
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "Goal progress recorded",
+			"data":    entry,
+		})
+	}
+}
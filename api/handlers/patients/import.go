@@ -0,0 +1,272 @@
+package patients
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"bitacora-medica-backend/api/database"
+	"bitacora-medica-backend/api/domains"
+	"bitacora-medica-backend/api/services"
+	"bitacora-medica-backend/api/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"gorm.io/gorm"
+)
+
+const maxImportRows = 2000
+
+// importFields son las columnas que se pueden mapear, con cómo se asignan al input de creación.
+var importFields = map[string]func(*CreatePatientInput, string){
+	"first_name":      func(in *CreatePatientInput, v string) { in.FirstName = v },
+	"last_name":       func(in *CreatePatientInput, v string) { in.LastName = v },
+	"rut":             func(in *CreatePatientInput, v string) { in.RUT = v },
+	"birth_date":      func(in *CreatePatientInput, v string) { in.BirthDate = v },
+	"email":           func(in *CreatePatientInput, v string) { in.Email = v },
+	"phone":           func(in *CreatePatientInput, v string) { in.Phone = v },
+	"diagnosis":       func(in *CreatePatientInput, v string) { in.Diagnosis = v },
+	"sex":             func(in *CreatePatientInput, v string) { in.Sex = v },
+	"emergency_phone": func(in *CreatePatientInput, v string) { in.EmergencyPhone = v },
+	"consent_pdf_url": func(in *CreatePatientInput, v string) { in.ConsentPDFUrl = v },
+}
+
+var requiredImportFields = []string{"first_name", "last_name", "rut", "birth_date", "email", "sex"}
+
+type ImportRowError struct {
+	Row    int      `json:"row"` // Número de fila en la planilla (la cabecera es la fila 1)
+	RUT    string   `json:"rut"`
+	Errors []string `json:"errors"`
+}
+
+// @Summary      Import patients from CSV/XLSX
+// @Description  Bulk import patients. mapping is a JSON object {"field": "Column header"}; without it, headers must match field names. With dry_run=true nothing is saved.
+// @Tags         Patients
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file     formData  file    true   "CSV or XLSX file"
+// @Param        mapping  formData  string  false  "Column mapping JSON"
+// @Param        dry_run  formData  boolean false  "Validate only"
+// @Success      200      {object}  map[string]interface{}
+// @Success      201      {object}  map[string]interface{}
+// @Failure      400      {object}  map[string]string
+// @Failure      409      {object}  map[string]string
+// @Router       /patients/import [post]
+// @Security     Bearer
+func ImportPatientsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+		dryRun := c.PostForm("dry_run") == "true"
+
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "File is required"})
+			return
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read file"})
+			return
+		}
+		defer file.Close()
+
+		ext := strings.ToLower(filepath.Ext(fileHeader.Filename))
+		var rows [][]string
+		switch ext {
+		case ".csv":
+			rows, err = utils.ReadCSV(file)
+		case ".xlsx":
+			rows, err = utils.ReadXLSX(file, fileHeader.Size)
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported file type. Options: .csv, .xlsx"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to parse file: " + err.Error()})
+			return
+		}
+
+		if len(rows) < 2 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "The file has no data rows"})
+			return
+		}
+		if len(rows)-1 > maxImportRows {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Too many rows (max %d per import)", maxImportRows)})
+			return
+		}
+
+		columns, msg := resolveImportColumns(rows[0], c.PostForm("mapping"))
+		if msg != "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+			return
+		}
+
+		// 1. Validación fila por fila con las mismas reglas que la creación individual
+		type importRow struct {
+			line  int
+			input CreatePatientInput
+		}
+		var candidates []importRow
+		var rowErrors []ImportRowError
+		seenRUTs := make(map[string]int)
+
+		for i, row := range rows[1:] {
+			line := i + 2
+			if isBlankRow(row) {
+				continue
+			}
+
+			var input CreatePatientInput
+			for field, col := range columns {
+				if col < len(row) {
+					importFields[field](&input, strings.TrimSpace(row[col]))
+				}
+			}
+			if ext == ".xlsx" {
+				if date, ok := utils.ExcelSerialToDate(input.BirthDate); ok {
+					input.BirthDate = date
+				}
+			}
+
+			var errs []string
+			if err := binding.Validator.ValidateStruct(&input); err != nil {
+				errs = append(errs, strings.Split(err.Error(), "\n")...)
+			}
+			if msg := validatePatientInput(input); msg != "" {
+				errs = append(errs, msg)
+			}
+
+			rut := utils.NormalizeRUT(input.RUT)
+			if first, dup := seenRUTs[rut]; dup && rut != "" {
+				errs = append(errs, fmt.Sprintf("Duplicate RUT in file (row %d)", first))
+			} else if rut != "" {
+				seenRUTs[rut] = line
+			}
+
+			if len(errs) > 0 {
+				rowErrors = append(rowErrors, ImportRowError{Row: line, RUT: input.RUT, Errors: errs})
+				continue
+			}
+			candidates = append(candidates, importRow{line: line, input: input})
+		}
+
+		// 2. Duplicados contra pacientes ya registrados
+		ruts := make([]string, 0, len(candidates))
+		for _, cand := range candidates {
+			ruts = append(ruts, utils.NormalizeRUT(cand.input.RUT))
+		}
+
+		db := database.GetDB()
+		existing, err := services.ExistingPatientRUTs(db, currentUser, ruts)
+		if err != nil {
+			slog.Error("Failed to check existing RUTs", "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate import"})
+			return
+		}
+
+		var toCreate []domains.Patient
+		for _, cand := range candidates {
+			if accessible, found := existing[utils.NormalizeRUT(cand.input.RUT)]; found {
+				// Si el paciente es de otro equipo no se confirma que el RUT esté registrado
+				msg := "This RUT cannot be imported. Contact an administrator."
+				if accessible {
+					msg = "A patient with this RUT already exists"
+				}
+				rowErrors = append(rowErrors, ImportRowError{Row: cand.line, RUT: cand.input.RUT, Errors: []string{msg}})
+				continue
+			}
+
+			patient, err := newPatient(currentUser.ID, cand.input)
+			if err != nil {
+				rowErrors = append(rowErrors, ImportRowError{Row: cand.line, RUT: cand.input.RUT, Errors: []string{"Failed to process personal info"}})
+				continue
+			}
+			toCreate = append(toCreate, patient)
+		}
+
+		summary := gin.H{
+			"dry_run":      dryRun,
+			"total_rows":   len(toCreate) + len(rowErrors),
+			"valid_rows":   len(toCreate),
+			"invalid_rows": len(rowErrors),
+			"errors":       rowErrors,
+		}
+
+		if dryRun || len(toCreate) == 0 {
+			c.JSON(http.StatusOK, gin.H{"data": summary})
+			return
+		}
+
+		// 3. Se guardan todas las filas válidas o ninguna
+		err = db.Transaction(func(tx *gorm.DB) error {
+			return tx.CreateInBatches(&toCreate, 100).Error
+		})
+		if services.IsUniqueViolation(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Some RUTs were registered while importing. Run the import again to see which rows failed."})
+			return
+		}
+		if err != nil {
+			slog.Error("Failed to import patients", "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import patients"})
+			return
+		}
+
+		summary["created"] = len(toCreate)
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "Patients imported successfully",
+			"data":    summary,
+		})
+	}
+}
+
+// resolveImportColumns asocia cada campo con el índice de su columna en la cabecera.
+func resolveImportColumns(header []string, mappingJSON string) (map[string]int, string) {
+	headerIndex := make(map[string]int, len(header))
+	for i, h := range header {
+		headerIndex[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+
+	mapping := make(map[string]string)
+	if mappingJSON != "" {
+		if err := json.Unmarshal([]byte(mappingJSON), &mapping); err != nil {
+			return nil, "Invalid mapping JSON"
+		}
+	} else {
+		for field := range importFields {
+			mapping[field] = field
+		}
+	}
+
+	columns := make(map[string]int)
+	for field, column := range mapping {
+		if _, ok := importFields[field]; !ok {
+			return nil, "Unknown field in mapping: " + field
+		}
+		if idx, ok := headerIndex[strings.ToLower(strings.TrimSpace(column))]; ok {
+			columns[field] = idx
+		} else if mappingJSON != "" {
+			return nil, fmt.Sprintf("Column %q not found in file", column)
+		}
+	}
+
+	for _, field := range requiredImportFields {
+		if _, ok := columns[field]; !ok {
+			return nil, "Missing column for required field: " + field
+		}
+	}
+
+	return columns, ""
+}
+
+func isBlankRow(row []string) bool {
+	for _, v := range row {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -1,15 +1,29 @@
 package patients
 
 import (
-	"fmt"
+	"errors"
 	"net/http"
+	"strings"
 
 	"bitacora-medica-backend/api/database"
 	"bitacora-medica-backend/api/domains"
+	"bitacora-medica-backend/api/pagination"
+	"bitacora-medica-backend/api/services"
+	"bitacora-medica-backend/api/utils"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
+var patientsPagination = pagination.Options{
+	Sorts: map[string]pagination.SortField{
+		"created_at": {Column: "created_at", Field: "CreatedAt", IsTime: true},
+		"updated_at": {Column: "updated_at", Field: "UpdatedAt", IsTime: true},
+	},
+	DefaultSort:  "created_at",
+	DefaultOrder: pagination.Desc,
+}
+
 // ListPatientsHandler devuelve la lista de pacientes
 // 1. Creados por el profesional actual
 // 2. O compartidos con él mediante una colaboración ACEPTADA
@@ -17,7 +31,15 @@ import (
 // @Description  List patients created by or shared with the professional
 // @Tags         Patients
 // @Produce      json
+// @Param        status     query     string  false  "Filter by status (ACTIVE, ON_HOLD, DISCHARGED, DECEASED)"
+// @Param        tags       query     string  false  "Comma-separated tag IDs (patients must have all of them)"
+// @Param        cohort_id  query     string  false  "Saved cohort ID"
+// @Param        sort       query     string  false  "created_at (default) or updated_at"
+// @Param        order      query     string  false  "asc or desc (default)"
+// @Param        limit      query     int     false  "Page size (max 100)"
+// @Param        cursor     query     string  false  "Cursor from the previous page"
 // @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /patients [get]
 // @Security     Bearer
@@ -28,26 +50,45 @@ func ListPatientsHandler() gin.HandlerFunc {
 
 		db := database.GetDB()
 
-		page := 1
-		limit := 10
-		if c.Query("page") != "" {
-			fmt.Sscan(c.Query("page"), &page)
-		}
-		if c.Query("limit") != "" {
-			fmt.Sscan(c.Query("limit"), &limit)
+		params, err := pagination.Parse(c, patientsPagination)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
 		}
-		offset := (page - 1) * limit
 
-		query := db.Where("creator_id = ?", currentUser.ID).
-			Or("id IN (?)", db.Table("collaborations").
-				Select("patient_id").
-				Where("professional_id = ? AND status = ?", currentUser.ID, domains.CollabAccepted))
+		query := db.Where("id IN (?)", services.AccessiblePatientIDs(db, currentUser.ID))
 
-		var total int64
-		query.Model(&domains.Patient{}).Count(&total)
+		if status := c.Query("status"); status != "" {
+			if !domains.PatientStatus(status).IsValid() {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status. Options: ACTIVE, ON_HOLD, DISCHARGED, DECEASED"})
+				return
+			}
+			query = query.Where("status = ?", status)
+		}
+
+		if tags := c.Query("tags"); tags != "" {
+			tagIDs, err := utils.ParseUUIDs(strings.Split(tags, ","))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			query = query.Where("id IN (?)", services.TaggedPatientIDs(db, tagIDs))
+		}
 
-		err := query.Limit(limit).Offset(offset).Find(&patients).Error
+		if cohortID := c.Query("cohort_id"); cohortID != "" {
+			cohortPatients, err := services.CohortScope(db, currentUser, cohortID)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Cohort not found"})
+				return
+			}
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			query = query.Where("id IN (?)", cohortPatients)
+		}
 
+		meta, err := params.Find(query, &patients)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch patients"})
 			return
@@ -55,12 +96,7 @@ func ListPatientsHandler() gin.HandlerFunc {
 
 		c.JSON(http.StatusOK, gin.H{
 			"data": patients,
-			"meta": gin.H{
-				"total":     total,
-				"page":      page,
-				"limit":     limit,
-				"last_page": (int(total) + limit - 1) / limit,
-			},
+			"meta": meta,
 		})
 	}
 }
@@ -0,0 +1,272 @@
+package patients
+
+import (
+	"net/http"
+	"time"
+
+	"bitacora-medica-backend/api/database"
+	"bitacora-medica-backend/api/domains"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary      List patient medications
+// @Description  List the medication list of a patient, optionally filtered by status
+// @Tags         Patients
+// @Produce      json
+// @Param        id      path      string  true   "Patient ID"
+// @Param        status  query     string  false  "ACTIVE or STOPPED"
+// @Success      200     {object}  map[string]interface{}
+// @Failure      403     {object}  map[string]string
+// @Router       /patients/{id}/medications [get]
+// @Security     Bearer
+func ListMedicationsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		patientID, _, ok := authorizePatient(c)
+		if !ok {
+			return
+		}
+
+		query := database.GetDB().Preload("RecordedBy").Where("patient_id = ?", patientID)
+
+		switch status := c.Query("status"); status {
+		case "":
+		case string(domains.MedicationActive), string(domains.MedicationStopped):
+			query = query.Where("status = ?", status)
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status. Options: ACTIVE, STOPPED"})
+			return
+		}
+
+		var medications []domains.PatientMedication
+		if err := query.Order("status ASC, start_date DESC").Find(&medications).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch medications"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"data": medications})
+	}
+}
+
+// @Summary      Add patient medication
+// @Description  Add a prescribed medication to the patient's medication list
+// @Tags         Patients
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string                         true  "Patient ID"
+// @Param        input  body      domains.CreateMedicationInput  true  "Medication Data"
+// @Success      201    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]string
+// @Failure      403    {object}  map[string]string
+// @Failure      500    {object}  map[string]string
+// @Router       /patients/{id}/medications [post]
+// @Security     Bearer
+func AddMedicationHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		patientID, currentUser, ok := authorizePatient(c)
+		if !ok {
+			return
+		}
+
+		var input domains.CreateMedicationInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		startDate := time.Now()
+		if input.StartDate != "" {
+			parsed, err := time.Parse("2006-01-02", input.StartDate)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start date format (YYYY-MM-DD)"})
+				return
+			}
+			startDate = parsed
+		}
+
+		medication := domains.PatientMedication{
+			PatientID:      patientID,
+			DrugName:       input.DrugName,
+			Dose:           input.Dose,
+			Route:          domains.MedicationRoute(input.Route),
+			Frequency:      input.Frequency,
+			PrescriberName: input.PrescriberName,
+			StartDate:      startDate,
+			Status:         domains.MedicationActive,
+			Notes:          input.Notes,
+			RecordedByID:   currentUser.ID,
+		}
+
+		if input.EndDate != "" {
+			endDate, err := time.Parse("2006-01-02", input.EndDate)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end date format (YYYY-MM-DD)"})
+				return
+			}
+			if endDate.Before(startDate) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "End date cannot be before start date"})
+				return
+			}
+			medication.EndDate = &endDate
+		}
+
+		if err := database.GetDB().Create(&medication).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save medication"})
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "Medication added successfully",
+			"data":    medication,
+		})
+	}
+}
+
+// @Summary      Update patient medication
+// @Description  Change dose, route, frequency, prescriber, end date or notes of an active medication
+// @Tags         Patients
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string                         true  "Patient ID"
+// @Param        med_id  path      string                         true  "Medication ID"
+// @Param        input   body      domains.UpdateMedicationInput  true  "Update Data"
+// @Success      200     {object}  map[string]interface{}
+// @Failure      400     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
+// @Failure      409     {object}  map[string]string
+// @Router       /patients/{id}/medications/{med_id} [put]
+// @Security     Bearer
+func UpdateMedicationHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		patientID, _, ok := authorizePatient(c)
+		if !ok {
+			return
+		}
+
+		var input domains.UpdateMedicationInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		db := database.GetDB()
+		var medication domains.PatientMedication
+		if err := db.First(&medication, "id = ? AND patient_id = ?", c.Param("med_id"), patientID).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Medication not found"})
+			return
+		}
+
+		if medication.Status == domains.MedicationStopped {
+			c.JSON(http.StatusConflict, gin.H{"error": "Stopped medications cannot be edited. Add a new medication instead."})
+			return
+		}
+
+		if input.Dose != "" {
+			medication.Dose = input.Dose
+		}
+		if input.Route != "" {
+			medication.Route = domains.MedicationRoute(input.Route)
+		}
+		if input.Frequency != "" {
+			medication.Frequency = input.Frequency
+		}
+		if input.PrescriberName != "" {
+			medication.PrescriberName = input.PrescriberName
+		}
+		if input.Notes != "" {
+			medication.Notes = input.Notes
+		}
+		if input.EndDate != "" {
+			endDate, err := time.Parse("2006-01-02", input.EndDate)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end date format (YYYY-MM-DD)"})
+				return
+			}
+			if endDate.Before(medication.StartDate) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "End date cannot be before start date"})
+				return
+			}
+			medication.EndDate = &endDate
+		}
+
+		if err := db.Save(&medication).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update medication"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Medication updated successfully",
+			"data":    medication,
+		})
+	}
+}
+
+// @Summary      Stop patient medication
+// @Description  Mark a medication as stopped with a reason
+// @Tags         Patients
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string                       true  "Patient ID"
+// @Param        med_id  path      string                       true  "Medication ID"
+// @Param        input   body      domains.StopMedicationInput  true  "Stop Data"
+// @Success      200     {object}  map[string]interface{}
+// @Failure      400     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
+// @Failure      409     {object}  map[string]string
+// @Router       /patients/{id}/medications/{med_id}/stop [post]
+// @Security     Bearer
+func StopMedicationHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		patientID, _, ok := authorizePatient(c)
+		if !ok {
+			return
+		}
+
+		var input domains.StopMedicationInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		endDate := time.Now()
+		if input.EndDate != "" {
+			parsed, err := time.Parse("2006-01-02", input.EndDate)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end date format (YYYY-MM-DD)"})
+				return
+			}
+			endDate = parsed
+		}
+
+		db := database.GetDB()
+		var medication domains.PatientMedication
+		if err := db.First(&medication, "id = ? AND patient_id = ?", c.Param("med_id"), patientID).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Medication not found"})
+			return
+		}
+
+		if medication.Status == domains.MedicationStopped {
+			c.JSON(http.StatusConflict, gin.H{"error": "Medication is already stopped"})
+			return
+		}
+
+		if input.EndDate != "" && endDate.Before(medication.StartDate) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "End date cannot be before start date"})
+			return
+		}
+
+		medication.Status = domains.MedicationStopped
+		medication.StopReason = input.Reason
+		medication.EndDate = &endDate
+
+		if err := db.Save(&medication).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to stop medication"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Medication stopped",
+			"data":    medication,
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package patients
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"bitacora-medica-backend/api/database"
+	"bitacora-medica-backend/api/domains"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var errInvalidTransition = errors.New("invalid status transition")
+
+// transitionPatientStatus cambia el estado del paciente y deja registro en el historial.
+func transitionPatientStatus(c *gin.Context, next domains.PatientStatus, reason string, effectiveDate time.Time) {
+	patientID, currentUser, ok := authorizePatient(c)
+	if !ok {
+		return
+	}
+
+	db := database.GetDB()
+
+	var patient domains.Patient
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&patient, "id = ?", patientID).Error; err != nil {
+			return err
+		}
+
+		if !patient.Status.CanTransitionTo(next) {
+			return errInvalidTransition
+		}
+
+		history := domains.PatientStatusHistory{
+			PatientID:     patient.ID,
+			FromStatus:    patient.Status,
+			ToStatus:      next,
+			Reason:        reason,
+			EffectiveDate: effectiveDate,
+			ChangedByID:   currentUser.ID,
+		}
+		if err := tx.Create(&history).Error; err != nil {
+			return err
+		}
+
+		patient.Status = next
+		patient.StatusReason = reason
+		switch next {
+		case domains.PatientDischarged:
+			patient.DischargedAt = &effectiveDate
+		case domains.PatientActive:
+			patient.DischargedAt = nil
+		}
+
+		return tx.Save(&patient).Error
+	})
+
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Patient not found"})
+		case errors.Is(err, errInvalidTransition):
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Cannot change patient status from " + string(patient.Status) + " to " + string(next),
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update patient status"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Patient status updated",
+		"data":    patient,
+	})
+}
+
+// @Summary      Discharge patient
+// @Description  Discharge a patient with a reason and date. New sessions are blocked until reactivated.
+// @Tags         Patients
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string                         true  "Patient ID"
+// @Param        input  body      domains.DischargePatientInput  true  "Discharge Data"
+// @Success      200    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]string
+// @Failure      403    {object}  map[string]string
+// @Failure      409    {object}  map[string]string
+// @Router       /patients/{id}/discharge [post]
+// @Security     Bearer
+func DischargePatientHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input domains.DischargePatientInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		date, err := time.Parse("2006-01-02", input.Date)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date format (YYYY-MM-DD)"})
+			return
+		}
+
+		transitionPatientStatus(c, domains.PatientDischarged, input.Reason, date)
+	}
+}
+
+// @Summary      Reactivate patient
+// @Description  Reopen a discharged or on-hold patient so new sessions can be recorded
+// @Tags         Patients
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string                          true  "Patient ID"
+// @Param        input  body      domains.ReactivatePatientInput  false "Reactivation Data"
+// @Success      200    {object}  map[string]interface{}
+// @Failure      403    {object}  map[string]string
+// @Failure      409    {object}  map[string]string
+// @Router       /patients/{id}/reactivate [post]
+// @Security     Bearer
+func ReactivatePatientHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input domains.ReactivatePatientInput
+		// El cuerpo es opcional
+		_ = c.ShouldBindJSON(&input)
+
+		transitionPatientStatus(c, domains.PatientActive, input.Reason, time.Now())
+	}
+}
+
+// @Summary      Change patient status
+// @Description  Put a patient on hold or mark them as deceased
+// @Tags         Patients
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string                            true  "Patient ID"
+// @Param        input  body      domains.ChangePatientStatusInput  true  "Status Data"
+// @Success      200    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]string
+// @Failure      403    {object}  map[string]string
+// @Failure      409    {object}  map[string]string
+// @Router       /patients/{id}/status [put]
+// @Security     Bearer
+func ChangePatientStatusHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input domains.ChangePatientStatusInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		date := time.Now()
+		if input.Date != "" {
+			parsed, err := time.Parse("2006-01-02", input.Date)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date format (YYYY-MM-DD)"})
+				return
+			}
+			date = parsed
+		}
+
+		transitionPatientStatus(c, domains.PatientStatus(input.Status), input.Reason, date)
+	}
+}
+
+// @Summary      Patient status history
+// @Description  List every status change of a patient, newest first
+// @Tags         Patients
+// @Produce      json
+// @Param        id   path      string  true  "Patient ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]string
+// @Router       /patients/{id}/status-history [get]
+// @Security     Bearer
+func GetPatientStatusHistoryHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		patientID, _, ok := authorizePatient(c)
+		if !ok {
+			return
+		}
+
+		db := database.GetDB()
+
+		var history []domains.PatientStatusHistory
+		if err := db.Preload("ChangedBy").
+			Where("patient_id = ?", patientID).
+			Order("created_at DESC").
+			Find(&history).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch status history"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"data": history})
+	}
+}
@@ -0,0 +1,128 @@
+package patients
+
+import (
+	"net/http"
+
+	"bitacora-medica-backend/api/database"
+	"bitacora-medica-backend/api/domains"
+	"bitacora-medica-backend/api/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary      List patient tags
+// @Description  List the tags attached to a patient that are visible to the user
+// @Tags         Patients
+// @Produce      json
+// @Param        id   path      string  true  "Patient ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]string
+// @Router       /patients/{id}/tags [get]
+// @Security     Bearer
+func ListPatientTagsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		patientID, currentUser, ok := authorizePatient(c)
+		if !ok {
+			return
+		}
+
+		db := database.GetDB()
+		var tags []domains.Tag
+		if err := services.VisibleTags(db, currentUser.ID).
+			Where("id IN (?)", db.Model(&domains.PatientTag{}).Select("tag_id").Where("patient_id = ?", patientID)).
+			Order("name ASC").
+			Find(&tags).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch tags"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"data": tags})
+	}
+}
+
+// @Summary      Attach tag to patient
+// @Description  Attach one of the user's personal or organization tags to a patient
+// @Tags         Patients
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string                  true  "Patient ID"
+// @Param        input  body      domains.AssignTagInput  true  "Tag"
+// @Success      200    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]string
+// @Failure      404    {object}  map[string]string
+// @Router       /patients/{id}/tags [post]
+// @Security     Bearer
+func AddPatientTagHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		patientID, currentUser, ok := authorizePatient(c)
+		if !ok {
+			return
+		}
+
+		var input domains.AssignTagInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		db := database.GetDB()
+		var tag domains.Tag
+		if err := services.VisibleTags(db, currentUser.ID).First(&tag, "id = ?", input.TagID).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Tag not found"})
+			return
+		}
+
+		patientTag := domains.PatientTag{
+			PatientID: patientID,
+			TagID:     tag.ID,
+			AddedByID: currentUser.ID,
+		}
+		if err := db.Where(domains.PatientTag{PatientID: patientID, TagID: tag.ID}).
+			FirstOrCreate(&patientTag).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to attach tag"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Tag attached successfully",
+			"data":    tag,
+		})
+	}
+}
+
+// @Summary      Detach tag from patient
+// @Tags         Patients
+// @Produce      json
+// @Param        id      path      string  true  "Patient ID"
+// @Param        tag_id  path      string  true  "Tag ID"
+// @Success      200     {object}  map[string]interface{}
+// @Failure      404     {object}  map[string]string
+// @Router       /patients/{id}/tags/{tag_id} [delete]
+// @Security     Bearer
+func RemovePatientTagHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		patientID, currentUser, ok := authorizePatient(c)
+		if !ok {
+			return
+		}
+
+		db := database.GetDB()
+		var tag domains.Tag
+		if err := services.VisibleTags(db, currentUser.ID).First(&tag, "id = ?", c.Param("tag_id")).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Tag not found"})
+			return
+		}
+
+		result := db.Where("patient_id = ? AND tag_id = ?", patientID, tag.ID).Delete(&domains.PatientTag{})
+		if result.Error != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to detach tag"})
+			return
+		}
+		if result.RowsAffected == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Tag is not attached to this patient"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Tag detached successfully"})
+	}
+}
@@ -0,0 +1,116 @@
+package patients
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"bitacora-medica-backend/api/database"
+	"bitacora-medica-backend/api/domains"
+	"bitacora-medica-backend/api/pagination"
+	"bitacora-medica-backend/api/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+var timelinePagination = pagination.Options{
+	Sorts: map[string]pagination.SortField{
+		"occurred_at": {Column: "occurred_at", Field: "OccurredAt", IsTime: true},
+	},
+	DefaultSort:  "occurred_at",
+	DefaultOrder: pagination.Desc,
+}
+
+// @Summary      Patient timeline
+// @Description  Chronological feed (newest first) of sessions, incidents, documents, reports, team changes, profile and status changes
+// @Tags         Patients
+// @Produce      json
+// @Param        id      path      string  true   "Patient ID"
+// @Param        types   query     string  false  "Comma-separated event types (SESSION, INCIDENT, DOCUMENT, REPORT, COLLABORATION_JOINED, COLLABORATION_REVOKED, PROFILE_CHANGE, STATUS_CHANGE)"
+// @Param        from    query     string  false  "From date (YYYY-MM-DD)"
+// @Param        to      query     string  false  "To date, inclusive (YYYY-MM-DD)"
+// @Param        order   query     string  false  "asc or desc (default)"
+// @Param        cursor  query     string  false  "Cursor from the previous page"
+// @Param        limit   query     int     false  "Page size (max 100)"
+// @Success      200     {object}  map[string]interface{}
+// @Failure      400     {object}  map[string]string
+// @Failure      403     {object}  map[string]string
+// @Router       /patients/{id}/timeline [get]
+// @Security     Bearer
+func GetPatientTimelineHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		patientID, _, ok := authorizePatient(c)
+		if !ok {
+			return
+		}
+
+		params, err := pagination.Parse(c, timelinePagination)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		filter := domains.TimelineFilter{
+			Limit:     params.Limit,
+			Ascending: params.Order == pagination.Asc,
+		}
+		if params.Cursor != nil {
+			after, _ := time.Parse(time.RFC3339Nano, params.Cursor.Value)
+			filter.AfterTime = &after
+			filter.AfterKey = params.Cursor.ID
+		}
+
+		if types := c.Query("types"); types != "" {
+			for _, t := range strings.Split(types, ",") {
+				eventType := domains.TimelineEventType(strings.TrimSpace(t))
+				if !eventType.IsValid() {
+					c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid event type: " + t})
+					return
+				}
+				filter.Types = append(filter.Types, eventType)
+			}
+		}
+
+		if from := c.Query("from"); from != "" {
+			fromDate, err := time.Parse("2006-01-02", from)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from date format (YYYY-MM-DD)"})
+				return
+			}
+			filter.From = &fromDate
+		}
+		if to := c.Query("to"); to != "" {
+			toDate, err := time.Parse("2006-01-02", to)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to date format (YYYY-MM-DD)"})
+				return
+			}
+			toDate = toDate.AddDate(0, 0, 1)
+			filter.To = &toDate
+		}
+
+		events, hasMore, err := services.PatientTimeline(database.GetDB(), patientID, filter)
+		if err != nil {
+			slog.Error("Failed to build patient timeline", "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch timeline"})
+			return
+		}
+
+		meta := pagination.Meta{Limit: params.Limit, Sort: params.Sort, Order: params.Order, HasMore: hasMore}
+		if hasMore {
+			last := events[len(events)-1]
+			meta.NextCursor = pagination.Cursor{
+				Sort:  params.Sort,
+				Order: params.Order,
+				Value: last.OccurredAt.UTC().Format(time.RFC3339Nano),
+				ID:    services.TimelineEventKey(last),
+			}.Encode()
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"data": events,
+			"meta": meta,
+		})
+	}
+}
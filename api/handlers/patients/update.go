@@ -7,6 +7,7 @@ import (
 	"bitacora-medica-backend/api/domains"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type UpdatePatientInput struct {
@@ -30,6 +31,7 @@ type UpdatePatientInput struct {
 func UpdatePatientHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id := c.Param("id")
+		currentUser := c.MustGet("currentUser").(domains.User)
 		var input UpdatePatientInput
 
 		if err := c.ShouldBindJSON(&input); err != nil {
@@ -45,10 +47,35 @@ func UpdatePatientHandler() gin.HandlerFunc {
 			return
 		}
 
+		// Cada campo modificado queda registrado para la línea de tiempo
+		var changes []domains.PatientProfileChange
+		trackChange := func(field, oldValue, newValue string) {
+			if oldValue != newValue {
+				changes = append(changes, domains.PatientProfileChange{
+					PatientID:   patient.ID,
+					Field:       field,
+					OldValue:    oldValue,
+					NewValue:    newValue,
+					ChangedByID: currentUser.ID,
+				})
+			}
+		}
+		trackChange("disability_report", patient.DisabilityReport, input.DisabilityReport)
+		trackChange("care_notes", patient.CareNotes, input.CareNotes)
+
 		patient.DisabilityReport = input.DisabilityReport
 		patient.CareNotes = input.CareNotes
 
-		if err := db.Save(&patient).Error; err != nil {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Save(&patient).Error; err != nil {
+				return err
+			}
+			if len(changes) > 0 {
+				return tx.Create(&changes).Error
+			}
+			return nil
+		})
+		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update patient"})
 			return
 		}
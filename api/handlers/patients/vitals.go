@@ -0,0 +1,136 @@
+package patients
+
+import (
+	"net/http"
+
+	"bitacora-medica-backend/api/database"
+	"bitacora-medica-backend/api/domains"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary      List vital thresholds
+// @Description  Per-patient acceptable ranges of vital signs. Values outside them notify the care team.
+// @Tags         Patients
+// @Produce      json
+// @Param        id   path      string  true  "Patient ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]string
+// @Router       /patients/{id}/vital-thresholds [get]
+// @Security     Bearer
+func ListVitalThresholdsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		patientID, _, ok := authorizePatient(c)
+		if !ok {
+			return
+		}
+
+		var thresholds []domains.VitalThreshold
+		if err := database.GetDB().Where("patient_id = ?", patientID).Order("vital_key ASC").Find(&thresholds).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch vital thresholds"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"data": thresholds})
+	}
+}
+
+// @Summary      Set vital threshold
+// @Description  Create or replace the acceptable range of a vital sign for the patient
+// @Tags         Patients
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string                          true  "Patient ID"
+// @Param        input  body      domains.SetVitalThresholdInput  true  "Threshold Data"
+// @Success      200    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]string
+// @Failure      403    {object}  map[string]string
+// @Router       /patients/{id}/vital-thresholds [put]
+// @Security     Bearer
+func SetVitalThresholdHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		patientID, currentUser, ok := authorizePatient(c)
+		if !ok {
+			return
+		}
+
+		var input domains.SetVitalThresholdInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		def, found := domains.LookupVital(domains.VitalKey(input.VitalKey))
+		if !found {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown vital sign: " + input.VitalKey})
+			return
+		}
+		if input.Min == nil && input.Max == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "A threshold requires min, max or both"})
+			return
+		}
+		if input.Min != nil && input.Max != nil && *input.Min >= *input.Max {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "min must be lower than max"})
+			return
+		}
+		for _, limit := range []*float64{input.Min, input.Max} {
+			if limit != nil && (*limit < def.Min || *limit > def.Max) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Threshold outside the plausible range of " + def.Label})
+				return
+			}
+		}
+
+		db := database.GetDB()
+		var threshold domains.VitalThreshold
+		db.Where("patient_id = ? AND vital_key = ?", patientID, def.Key).Limit(1).Find(&threshold)
+
+		threshold.PatientID = patientID
+		threshold.VitalKey = def.Key
+		threshold.Min = input.Min
+		threshold.Max = input.Max
+		threshold.CreatedByID = currentUser.ID
+
+		if err := db.Save(&threshold).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save vital threshold"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Vital threshold saved",
+			"data":    threshold,
+		})
+	}
+}
+
+// @Summary      Delete vital threshold
+// @Description  Stop monitoring a vital sign for the patient
+// @Tags         Patients
+// @Produce      json
+// @Param        id         path      string  true  "Patient ID"
+// @Param        vital_key  path      string  true  "Vital key"
+// @Success      200        {object}  map[string]interface{}
+// @Failure      404        {object}  map[string]string
+// @Router       /patients/{id}/vital-thresholds/{vital_key} [delete]
+// @Security     Bearer
+func DeleteVitalThresholdHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		patientID, _, ok := authorizePatient(c)
+		if !ok {
+			return
+		}
+
+		result := database.GetDB().
+			Where("patient_id = ? AND vital_key = ?", patientID, c.Param("vital_key")).
+			Delete(&domains.VitalThreshold{})
+		if result.Error != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete vital threshold"})
+			return
+		}
+		if result.RowsAffected == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Vital threshold not found"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Vital threshold deleted"})
+	}
+}
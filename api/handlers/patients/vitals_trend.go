@@ -0,0 +1,90 @@
+package patients
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"bitacora-medica-backend/api/database"
+	"bitacora-medica-backend/api/domains"
+	"bitacora-medica-backend/api/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+const defaultVitalTrendDays = 90
+
+// @Summary      Vital signs trend
+// @Description  Time series of the patient's vital signs, optionally downsampled to daily or weekly min/max/avg
+// @Tags         Patients
+// @Produce      json
+// @Param        id        path      string  true   "Patient ID"
+// @Param        keys      query     string  false  "Comma-separated vital keys (default: all)"
+// @Param        from      query     string  false  "From date (YYYY-MM-DD, default 90 days ago)"
+// @Param        to        query     string  false  "To date, inclusive (YYYY-MM-DD, default today)"
+// @Param        interval  query     string  false  "day or week (default: every measurement)"
+// @Success      200       {object}  map[string]interface{}
+// @Failure      400       {object}  map[string]string
+// @Failure      403       {object}  map[string]string
+// @Router       /patients/{id}/vitals/trend [get]
+// @Security     Bearer
+func GetVitalsTrendHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		patientID, _, ok := authorizePatient(c)
+		if !ok {
+			return
+		}
+
+		var keys []domains.VitalKey
+		if raw := c.Query("keys"); raw != "" {
+			for _, k := range strings.Split(raw, ",") {
+				def, found := domains.LookupVital(domains.VitalKey(strings.TrimSpace(k)))
+				if !found {
+					c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown vital sign: " + k})
+					return
+				}
+				keys = append(keys, def.Key)
+			}
+		}
+
+		interval := domains.VitalTrendInterval(c.Query("interval"))
+		if interval != domains.VitalTrendRaw && interval != domains.VitalTrendDay && interval != domains.VitalTrendWeek {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid interval. Options: day, week"})
+			return
+		}
+
+		now := time.Now()
+		to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, 1)
+		if value := c.Query("to"); value != "" {
+			parsed, err := time.Parse("2006-01-02", value)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to date format (YYYY-MM-DD)"})
+				return
+			}
+			to = parsed.AddDate(0, 0, 1)
+		}
+		from := to.AddDate(0, 0, -defaultVitalTrendDays)
+		if value := c.Query("from"); value != "" {
+			parsed, err := time.Parse("2006-01-02", value)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from date format (YYYY-MM-DD)"})
+				return
+			}
+			from = parsed
+		}
+		if !from.Before(to) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "from must be before to"})
+			return
+		}
+
+		series, err := services.VitalTrends(database.GetDB(), patientID, keys, from, to, interval)
+		if err != nil {
+			slog.Error("Failed to compute vitals trend", "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compute vitals trend"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"data": series})
+	}
+}
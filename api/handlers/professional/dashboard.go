@@ -1,20 +1,31 @@
 package professional
 
 import (
+	"errors"
 	"net/http"
 	"time"
 
 	"bitacora-medica-backend/api/database"
 	"bitacora-medica-backend/api/domains"
+	"bitacora-medica-backend/api/services"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	attendanceWindowDays  = 90
+	maxAttendancePatients = 10
 )
 
 // @Summary      Get dashboard summary
 // @Description  Get dashboard statistics for the professional
 // @Tags         Professional
 // @Produce      json
+// @Param        status     query     string  false  "Patient status to count (default ACTIVE)"
+// @Param        cohort_id  query     string  false  "Scope the dashboard to a saved cohort"
 // @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
 // @Router       /dashboard/summary [get]
 // @Security     Bearer
 func GetMyDashboardHandler() gin.HandlerFunc {
@@ -24,18 +35,82 @@ func GetMyDashboardHandler() gin.HandlerFunc {
 
 		stats := domains.ProfessionalDashboardStats{}
 
-		db.Model(&domains.Patient{}).Count(&stats.ActivePatients)
+		patientStatus := domains.PatientActive
+		if status := c.Query("status"); status != "" {
+			if !domains.PatientStatus(status).IsValid() {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status. Options: ACTIVE, ON_HOLD, DISCHARGED, DECEASED"})
+				return
+			}
+			patientStatus = domains.PatientStatus(status)
+		}
+
+		myPatients := services.AccessiblePatientIDs(db, currentUser.ID)
+
+		// Con cohort_id, todas las métricas se limitan a los pacientes de la cohorte
+		var cohortPatients *gorm.DB
+		if cohortID := c.Query("cohort_id"); cohortID != "" {
+			scope, err := services.CohortScope(db, currentUser, cohortID)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Cohort not found"})
+				return
+			}
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			cohortPatients = scope
+		}
+
+		patientScope := func() *gorm.DB {
+			query := db.Model(&domains.Patient{}).Where("id IN (?)", myPatients)
+			if cohortPatients != nil {
+				query = query.Where("id IN (?)", cohortPatients)
+			}
+			return query
+		}
+		sessionScope := func() *gorm.DB {
+			query := db.Model(&domains.Session{}).Where("professional_id = ?", currentUser.ID)
+			if cohortPatients != nil {
+				query = query.Where("patient_id IN (?)", cohortPatients)
+			}
+			return query
+		}
+
+		incidentScope := func() *gorm.DB {
+			query := db.Model(&domains.Incident{}).Where("reported_by_id = ?", currentUser.ID)
+			if cohortPatients != nil {
+				query = query.Where("patient_id IN (?)", cohortPatients)
+			}
+			return query
+		}
+
+		attendanceScope := func() *gorm.DB {
+			query := db.Model(&domains.AttendanceRecord{}).
+				Where("professional_id = ? AND occurred_at >= ?", currentUser.ID, time.Now().AddDate(0, 0, -attendanceWindowDays))
+			if cohortPatients != nil {
+				query = query.Where("patient_id IN (?)", cohortPatients)
+			}
+			return query
+		}
+
+		patientScope().Where("status = ?", patientStatus).Count(&stats.ActivePatients)
+		stats.PatientsByStatus = services.CountPatientsByStatus(patientScope())
 
 		now := time.Now()
 		startOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
 
-		db.Model(&domains.Session{}).
-			Where("professional_id = ? AND created_at >= ?", currentUser.ID, startOfMonth).
-			Count(&stats.MonthlySessions)
+		sessionScope().Where("created_at >= ?", startOfMonth).Count(&stats.MonthlySessions)
+
+		incidentScope().Count(&stats.ReportedIncidents)
+		stats.IncidentsByStatus = services.CountIncidentsByStatus(incidentScope())
 
-		db.Model(&domains.Session{}).
-			Where("professional_id = ? AND has_incident = ?", currentUser.ID, true).
-			Count(&stats.ReportedIncidents)
+		stats.Attendance = services.SummarizeAttendance(attendanceScope())
+
+		// Pacientes con menor asistencia primero, para hacerles seguimiento
+		attendanceByPatient := services.AttendanceByPatient(db, attendanceScope())
+		if len(attendanceByPatient) > maxAttendancePatients {
+			attendanceByPatient = attendanceByPatient[:maxAttendancePatients]
+		}
 
 		spanishDays := map[string]string{
 			"Monday":    "Lun",
@@ -65,12 +140,11 @@ func GetMyDashboardHandler() gin.HandlerFunc {
 		}
 		var results []DailyResult
 
-		db.Raw(`
-			SELECT to_char(created_at, 'YYYY-MM-DD') as date, count(*) as count
-			FROM sessions 
-			WHERE professional_id = ? AND created_at >= ?
-			GROUP BY to_char(created_at, 'YYYY-MM-DD')
-		`, currentUser.ID, time.Now().AddDate(0, 0, -7)).Scan(&results)
+		sessionScope().
+			Select("to_char(created_at, 'YYYY-MM-DD') as date, count(*) as count").
+			Where("created_at >= ?", time.Now().AddDate(0, 0, -7)).
+			Group("to_char(created_at, 'YYYY-MM-DD')").
+			Scan(&results)
 
 		for _, r := range results {
 			if _, exists := activityMap[r.Date]; exists {
@@ -90,18 +164,20 @@ func GetMyDashboardHandler() gin.HandlerFunc {
 		}
 
 		var recentPatients []domains.Patient
-		db.Raw(`
-			SELECT DISTINCT ON (p.id) p.* FROM patients p
-			JOIN sessions s ON s.patient_id = p.id
-			WHERE s.professional_id = ?
-			ORDER BY p.id, s.created_at DESC
-			LIMIT 5
-		`, currentUser.ID).Scan(&recentPatients)
+		recentQuery := db.Table("patients p").
+			Select("DISTINCT ON (p.id) p.*").
+			Joins("JOIN sessions s ON s.patient_id = p.id").
+			Where("s.professional_id = ?", currentUser.ID)
+		if cohortPatients != nil {
+			recentQuery = recentQuery.Where("p.id IN (?)", cohortPatients)
+		}
+		recentQuery.Order("p.id, s.created_at DESC").Limit(5).Scan(&recentPatients)
 
 		c.JSON(http.StatusOK, gin.H{
-			"stats":           stats,
-			"activity":        finalActivity,
-			"recent_patients": recentPatients,
+			"stats":                 stats,
+			"activity":              finalActivity,
+			"recent_patients":       recentPatients,
+			"attendance_by_patient": attendanceByPatient,
 		})
 	}
 }
@@ -1,13 +1,17 @@
 package reports
 
 import (
+	"log/slog"
 	"net/http"
 	"time"
 
 	"bitacora-medica-backend/api/database"
 	"bitacora-medica-backend/api/domains"
+	"bitacora-medica-backend/api/services"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type MasterReportResponse struct {
@@ -18,6 +22,36 @@ type MasterReportResponse struct {
 	TotalIncidents int64 `json:"total_incidents"`
 
 	ProfessionalSummaries []ProfessionalSummary `json:"professional_summaries"`
+
+	GoalAttainment GoalAttainmentSummary `json:"goal_attainment"`
+
+	Attendance AttendanceSummary `json:"attendance"`
+
+	VitalTrends []domains.VitalSeries `json:"vital_trends"` // Resumen semanal
+}
+
+type AttendanceSummary struct {
+	Overall        domains.AttendanceStats               `json:"overall"`
+	ByProfessional []domains.ProfessionalAttendanceStats `json:"by_professional"`
+}
+
+type GoalAttainmentSummary struct {
+	TotalGoals     int            `json:"total_goals"`
+	ByStatus       map[string]int `json:"by_status"`
+	AttainmentRate float64        `json:"attainment_rate"` // logrados / objetivos cerrados
+	Goals          []GoalSummary  `json:"goals"`
+}
+
+type GoalSummary struct {
+	Title           string `json:"title"`
+	Baseline        string `json:"baseline"`
+	Target          string `json:"target"`
+	Owner           string `json:"owner"`
+	Status          string `json:"status"`
+	DueDate         string `json:"due_date,omitempty"`
+	StartScore      *int   `json:"start_score"`
+	EndScore        *int   `json:"end_score"`
+	ProgressEntries int    `json:"progress_entries"`
 }
 
 type ProfessionalSummary struct {
@@ -36,6 +70,7 @@ type ProfessionalSummary struct {
 // @Param        end_date   query string true "End Date (YYYY-MM-DD)"
 // @Success      200  {object}  map[string]interface{}
 // @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /reports/master [get]
 // @Security     Bearer
@@ -47,8 +82,20 @@ func GenerateMasterReportHandler() gin.HandlerFunc {
 			return
 		}
 
+		patientID, err := uuid.Parse(req.PatientID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid patient ID"})
+			return
+		}
+
 		db := database.GetDB()
 
+		currentUser := c.MustGet("currentUser").(domains.User)
+		if !services.CanAccessPatient(db, currentUser, patientID) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You are not part of this patient's care team"})
+			return
+		}
+
 		var reports []domains.ProfessionalReport
 		if err := db.Preload("Author").
 			Where("patient_id = ? AND date_range_start >= ? AND date_range_end <= ?",
@@ -81,14 +128,130 @@ func GenerateMasterReportHandler() gin.HandlerFunc {
 			})
 		}
 
+		goalAttainment := summarizeGoalAttainment(req)
+
+		attendanceScope := func() *gorm.DB {
+			return db.Model(&domains.AttendanceRecord{}).
+				Where("patient_id = ? AND occurred_at >= ? AND occurred_at < ?::date + 1", req.PatientID, req.StartDate, req.EndDate)
+		}
+		attendance := AttendanceSummary{
+			Overall:        services.SummarizeAttendance(attendanceScope()),
+			ByProfessional: services.AttendanceByProfessional(db, attendanceScope()),
+		}
+
+		vitalTrends := summarizeVitalTrends(req)
+
 		response := MasterReportResponse{
 			GeneratedAt:           time.Now(),
 			DateRange:             req.StartDate + " to " + req.EndDate,
 			TotalSessions:         totalSessions,
 			TotalIncidents:        totalIncidents,
 			ProfessionalSummaries: summaries,
+			GoalAttainment:        goalAttainment,
+			Attendance:            attendance,
+			VitalTrends:           vitalTrends,
 		}
 
 		c.JSON(http.StatusOK, gin.H{"data": response})
 	}
 }
+
+// summarizeGoalAttainment resume los objetivos vigentes en el rango: los abiertos
+// y los cerrados dentro del período, con el primer y último puntaje registrado.
+func summarizeGoalAttainment(req domains.MasterReportRequest) GoalAttainmentSummary {
+	db := database.GetDB()
+
+	var goals []domains.TherapeuticGoal
+	db.Preload("Owner").
+		Where("patient_id = ? AND created_at <= ?", req.PatientID, req.EndDate).
+		Where("closed_at IS NULL OR closed_at >= ?", req.StartDate).
+		Where("status <> ?", domains.GoalCancelled).
+		Order("created_at ASC").
+		Find(&goals)
+
+	var progress []domains.GoalProgress
+	db.Where("patient_id = ? AND created_at BETWEEN ? AND ?", req.PatientID, req.StartDate, req.EndDate).
+		Order("created_at ASC").
+		Find(&progress)
+
+	progressByGoal := make(map[string][]domains.GoalProgress)
+	for _, p := range progress {
+		progressByGoal[p.GoalID.String()] = append(progressByGoal[p.GoalID.String()], p)
+	}
+
+	summary := GoalAttainmentSummary{
+		TotalGoals: len(goals),
+		ByStatus:   make(map[string]int),
+		Goals:      []GoalSummary{},
+	}
+
+	closed, achieved := 0, 0
+	for _, g := range goals {
+		summary.ByStatus[string(g.Status)]++
+		if g.Status != domains.GoalActive {
+			closed++
+		}
+		if g.Status == domains.GoalAchieved {
+			achieved++
+		}
+
+		item := GoalSummary{
+			Title:    g.Title,
+			Baseline: g.Baseline,
+			Target:   g.Target,
+			Owner:    g.Owner.Email,
+			Status:   string(g.Status),
+		}
+		if g.DueDate != nil {
+			item.DueDate = g.DueDate.Format("2006-01-02")
+		}
+
+		entries := progressByGoal[g.ID.String()]
+		item.ProgressEntries = len(entries)
+		for _, e := range entries {
+			if e.Score == nil {
+				continue
+			}
+			if item.StartScore == nil {
+				item.StartScore = e.Score
+			}
+			item.EndScore = e.Score
+		}
+
+		summary.Goals = append(summary.Goals, item)
+	}
+
+	if closed > 0 {
+		summary.AttainmentRate = float64(achieved) / float64(closed)
+	}
+
+	return summary
+}
+
+// summarizeVitalTrends agrupa por semana los signos vitales registrados en el rango,
+// omitiendo los que no tienen mediciones.
+func summarizeVitalTrends(req domains.MasterReportRequest) []domains.VitalSeries {
+	patientID, err := uuid.Parse(req.PatientID)
+	if err != nil {
+		return []domains.VitalSeries{}
+	}
+	from, errFrom := time.Parse("2006-01-02", req.StartDate)
+	to, errTo := time.Parse("2006-01-02", req.EndDate)
+	if errFrom != nil || errTo != nil {
+		return []domains.VitalSeries{}
+	}
+
+	series, err := services.VitalTrends(database.GetDB(), patientID, nil, from, to.AddDate(0, 0, 1), domains.VitalTrendWeek)
+	if err != nil {
+		slog.Error("Failed to compute vitals trend for master report", "error", err)
+		return []domains.VitalSeries{}
+	}
+
+	withData := make([]domains.VitalSeries, 0, len(series))
+	for _, s := range series {
+		if len(s.Points) > 0 {
+			withData = append(withData, s)
+		}
+	}
+	return withData
+}
@@ -5,15 +5,29 @@ import (
 
 	"bitacora-medica-backend/api/database"
 	"bitacora-medica-backend/api/domains"
+	"bitacora-medica-backend/api/pagination"
 
 	"github.com/gin-gonic/gin"
 )
 
+var reportsPagination = pagination.Options{
+	Sorts: map[string]pagination.SortField{
+		"date_range_end": {Column: "date_range_end", Field: "DateRangeEnd", IsTime: true},
+		"created_at":     {Column: "created_at", Field: "CreatedAt", IsTime: true},
+	},
+	DefaultSort:  "date_range_end",
+	DefaultOrder: pagination.Desc,
+}
+
 // @Summary      List patient reports
 // @Description  List reports for a specific patient
 // @Tags         Reports
 // @Produce      json
 // @Param        patient_id query string true "Patient ID"
+// @Param        sort       query string false "date_range_end (default) or created_at"
+// @Param        order      query string false "asc or desc (default)"
+// @Param        limit      query int    false "Page size (max 100)"
+// @Param        cursor     query string false "Cursor from the previous page"
 // @Success      200  {object}  map[string]interface{}
 // @Failure      400  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
@@ -27,17 +41,21 @@ func ListPatientReportsHandler() gin.HandlerFunc {
 			return
 		}
 
+		params, err := pagination.Parse(c, reportsPagination)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
 		db := database.GetDB()
 		var reports []domains.ProfessionalReport
 
-		if err := db.Preload("Author").
-			Where("patient_id = ?", patientID).
-			Order("date_range_end DESC").
-			Find(&reports).Error; err != nil {
+		meta, err := params.Find(db.Preload("Author").Where("patient_id = ?", patientID), &reports)
+		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch reports"})
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{"data": reports})
+		c.JSON(http.StatusOK, gin.H{"data": reports, "meta": meta})
 	}
 }
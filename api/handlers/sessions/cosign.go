@@ -0,0 +1,191 @@
+package sessions
+
+import (
+	"net/http"
+	"time"
+
+	"bitacora-medica-backend/api/config"
+	"bitacora-medica-backend/api/database"
+	"bitacora-medica-backend/api/domains"
+	"bitacora-medica-backend/api/pagination"
+	"bitacora-medica-backend/api/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var cosignQueuePagination = pagination.Options{
+	Sorts: map[string]pagination.SortField{
+		"created_at": {Column: "created_at", Field: "CreatedAt", IsTime: true},
+	},
+	DefaultSort:  "created_at",
+	DefaultOrder: pagination.Asc,
+}
+
+// @Summary      Co-signature queue
+// @Description  List sessions of the supervisor's trainees awaiting co-signature, oldest first
+// @Tags         Supervision
+// @Produce      json
+// @Param        status  query     string  false  "PENDING (default) or RETURNED"
+// @Param        limit   query     int     false  "Page size (max 100)"
+// @Param        cursor  query     string  false  "Cursor from the previous page"
+// @Success      200     {object}  map[string]interface{}
+// @Failure      400     {object}  map[string]string
+// @Router       /supervision/queue [get]
+// @Security     Bearer
+func ListCosignQueueHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+		db := database.GetDB()
+
+		params, err := pagination.Parse(c, cosignQueuePagination)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		status := domains.CosignStatus(c.DefaultQuery("status", string(domains.CosignPending)))
+		if !status.AwaitingCosign() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status. Options: PENDING, RETURNED"})
+			return
+		}
+
+		query := db.Model(&domains.Session{}).
+			Preload("Creator").
+			Where("cosign_status = ? AND professional_id IN (?)", status, services.TraineeIDs(db, currentUser.ID))
+
+		var sessions []domains.Session
+		meta, err := params.Find(query, &sessions)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch co-signature queue"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"data": sessions, "meta": meta})
+	}
+}
+
+// loadSupervisedSession carga la sesión de :id si el usuario supervisa a su autor
+// y la sesión espera su firma.
+func loadSupervisedSession(c *gin.Context, currentUser domains.User) (domains.Session, bool) {
+	db := database.GetDB()
+
+	var session domains.Session
+	if err := db.First(&session, "id = ?", c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		return session, false
+	}
+
+	if !services.IsSupervisorOf(db, currentUser.ID, session.ProfessionalID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the author's supervisor can co-sign this session"})
+		return session, false
+	}
+
+	if session.CosignStatus != domains.CosignPending {
+		c.JSON(http.StatusConflict, gin.H{"error": "Session is not pending co-signature (" + string(session.CosignStatus) + ")"})
+		return session, false
+	}
+
+	return session, true
+}
+
+// updateCosign guarda solo las columnas de co-firma, condicionado a que la sesión no
+// haya cambiado desde que se cargó: si el autor la editó entretanto responde 409.
+func updateCosign(c *gin.Context, session *domains.Session, fields map[string]interface{}) bool {
+	db := database.GetDB()
+
+	fields["version"] = gorm.Expr("version + 1")
+	result := db.Model(&domains.Session{}).
+		Where("id = ? AND version = ? AND cosign_status = ?", session.ID, session.Version, domains.CosignPending).
+		Updates(fields)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update co-signature"})
+		return false
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "The session was modified while you reviewed it. Reload it and try again."})
+		return false
+	}
+
+	db.First(session, "id = ?", session.ID)
+	return true
+}
+
+// @Summary      Approve session
+// @Description  Co-sign a trainee's session (supervisor only)
+// @Tags         Supervision
+// @Produce      json
+// @Param        id   path      string  true  "Session ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Router       /sessions/{id}/cosign/approve [post]
+// @Security     Bearer
+func ApproveSessionHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+
+		session, ok := loadSupervisedSession(c, currentUser)
+		if !ok {
+			return
+		}
+
+		ok = updateCosign(c, &session, map[string]interface{}{
+			"cosign_status":   domains.CosignApproved,
+			"cosigned_by_id":  currentUser.ID,
+			"cosigned_at":     time.Now(),
+			"cosign_comments": "",
+		})
+		if !ok {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Session co-signed", "data": session})
+	}
+}
+
+// @Summary      Return session
+// @Description  Return a trainee's session with comments so the author can correct it (supervisor only)
+// @Tags         Supervision
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string                      true  "Session ID"
+// @Param        input  body      domains.ReturnSessionInput  true  "Supervisor comments"
+// @Success      200    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]string
+// @Failure      403    {object}  map[string]string
+// @Failure      404    {object}  map[string]string
+// @Failure      409    {object}  map[string]string
+// @Router       /sessions/{id}/cosign/return [post]
+// @Security     Bearer
+func ReturnSessionHandler(cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+
+		var input domains.ReturnSessionInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		session, ok := loadSupervisedSession(c, currentUser)
+		if !ok {
+			return
+		}
+
+		ok = updateCosign(c, &session, map[string]interface{}{
+			"cosign_status":   domains.CosignReturned,
+			"cosigned_by_id":  currentUser.ID,
+			"cosigned_at":     nil,
+			"cosign_comments": input.Comments,
+		})
+		if !ok {
+			return
+		}
+
+		go services.NewNotificationService(cfg).NotifySessionReturned(session, currentUser, input.Comments)
+
+		c.JSON(http.StatusOK, gin.H{"message": "Session returned to its author", "data": session})
+	}
+}
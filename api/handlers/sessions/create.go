@@ -1,7 +1,7 @@
 package sessions
 
 import (
-	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 
@@ -13,7 +13,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/lib/pq"
-	"gorm.io/datatypes"
+	"gorm.io/gorm"
 )
 
 // CreateSessionHandler ahora requiere la configuración para enviar correos
@@ -25,6 +25,9 @@ import (
 // @Param        input body domains.CreateSessionInput true "Session Data"
 // @Success      201  {object}  map[string]interface{}
 // @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /sessions [post]
 // @Security     Bearer
@@ -40,57 +43,213 @@ func CreateSessionHandler(cfg *config.Config) gin.HandlerFunc {
 			return
 		}
 
-		if input.HasIncident && input.IncidentDetails == "" {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": "Incident details are mandatory when an incident is reported.",
-			})
-			return
-		}
+		recordSession(c, cfg, currentUser, input, nil)
+	}
+}
+
+// sessionResult es lo que deja createSession para responder y notificar.
+type sessionResult struct {
+	Session          domains.Session
+	MedicationEvents []domains.SessionMedicationEvent
+	GoalProgress     []domains.GoalProgress
+	AbnormalVitals   []domains.AbnormalVital
+	Mentions         []domains.Mention
+}
+
+// createSession valida y guarda la sesión con sus efectos (cita, asistencia,
+// medicación, objetivos) dentro de tx. No envía notificaciones: eso se hace con
+// notifySession una vez confirmada la transacción. session trae los valores que
+// fija el llamador (ej: ID y fecha generados offline).
+func createSession(tx *gorm.DB, currentUser domains.User, input domains.CreateSessionInput, session domains.Session) (sessionResult, error) {
+	var result sessionResult
+
+	if input.HasIncident && input.IncidentDetails == "" {
+		return result, badInput("Incident details are mandatory when an incident is reported.")
+	}
 
-		patientID, err := uuid.Parse(input.PatientID)
+	if input.TemplateID != "" {
+		var template domains.SessionTemplate
+		if err := services.VisibleSessionTemplates(tx, currentUser.ID).First(&template, "id = ?", input.TemplateID).Error; err != nil {
+			return result, badInput("Session template not found")
+		}
+		extraFields, err := services.ApplySessionTemplate(template, &input)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Patient ID"})
-			return
+			return result, badInput(err.Error())
 		}
+		session.TemplateID = &template.ID
+		session.ExtraFields = extraFields
+	} else if len(input.ExtraFields) > 0 {
+		return result, badInput("extra_fields require a template_id")
+	}
 
-		vitalsJSON, _ := json.Marshal(input.Vitals)
-
-		session := domains.Session{
-			PatientID:          patientID,
-			ProfessionalID:     currentUser.ID,
-			InterventionPlan:   input.InterventionPlan,
-			Vitals:             datatypes.JSON(vitalsJSON),
-			Description:        input.Description,
-			Achievements:       input.Achievements,
-			PatientPerformance: input.PatientPerformance,
-			Photos:             pq.StringArray(input.Photos),
-			HasIncident:        input.HasIncident,
-			IncidentDetails:    input.IncidentDetails,
-			IncidentPhoto:      input.IncidentPhoto,
-			NextSessionNotes:   input.NextSessionNotes,
-		}
+	if input.InterventionPlan == "" {
+		return result, badInput("intervention_plan is required")
+	}
 
-		if err := database.DB.Create(&session).Error; err != nil {
-			slog.Error("Failed to create session", "error", err)
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save session"})
-			return
+	patientID, err := uuid.Parse(input.PatientID)
+	if err != nil {
+		return result, badInput("Invalid Patient ID")
+	}
+
+	var patient domains.Patient
+	if err := tx.Select("id", "status").First(&patient, "id = ?", patientID).Error; err != nil {
+		return result, notFoundInput("Patient not found")
+	}
+
+	// Todos los caminos (individual, grupal, borrador, sync) pasan por aquí
+	if !services.CanAccessPatient(tx, currentUser, patientID) {
+		return result, forbiddenInput("You do not have access to this patient")
+	}
+
+	if !patient.Status.AcceptsNewSessions() {
+		return result, conflictInput("Patient is " + string(patient.Status) + ". Reactivate the patient before recording new sessions.")
+	}
+
+	var appointment *domains.Appointment
+	if input.AppointmentID != "" {
+		appointment = &domains.Appointment{}
+		if err := tx.First(appointment, "id = ? AND patient_id = ?", input.AppointmentID, patientID).Error; err != nil {
+			return result, badInput("Appointment not found for this patient")
+		}
+		if !appointment.Status.IsOpen() {
+			return result, conflictInput("Appointment is already " + string(appointment.Status))
 		}
+		session.AppointmentID = &appointment.ID
+	}
+
+	vitalsJSON, vitals, err := parseVitals(input.Vitals)
+	if err != nil {
+		return result, badInput(err.Error())
+	}
 
-		if session.HasIncident {
+	session.PatientID = patientID
+	session.ProfessionalID = currentUser.ID
+	session.InterventionPlan = input.InterventionPlan
+	session.Vitals = vitalsJSON
+	session.Description = input.Description
+	session.Achievements = input.Achievements
+	session.PatientPerformance = input.PatientPerformance
+	session.Photos = pq.StringArray(input.Photos)
+	session.HasIncident = input.HasIncident
+	session.IncidentDetails = input.IncidentDetails
+	session.IncidentPhoto = input.IncidentPhoto
+	session.NextSessionNotes = input.NextSessionNotes
+	session.Version = 1
+	session.CosignStatus = services.InitialCosignStatus(tx, currentUser.ID)
 
-			go func() {
-				notifier := services.NewNotificationService(cfg)
-				notifier.NotifyIncident(session.PatientID, session.IncidentDetails)
+	if err := tx.Create(&session).Error; err != nil {
+		return result, err
+	}
+
+	if session.HasIncident {
+		if err := services.OpenSessionIncident(tx, session, input.IncidentSeverity, input.IncidentCategory); err != nil {
+			return result, err
+		}
+	}
 
-				slog.Warn("INCIDENT REPORTED - Notifications triggered",
-					"patient_id", session.PatientID,
-					"professional", currentUser.Email)
-			}()
+	if appointment != nil {
+		appointment.Status = domains.AppointmentCompleted
+		appointment.SessionID = &session.ID
+		if err := tx.Save(appointment).Error; err != nil {
+			return result, err
 		}
+		err := services.RecordAppointmentAttendance(tx, *appointment, domains.AttendanceAttended, "", currentUser.ID, &session.ID)
+		if err != nil {
+			return result, err
+		}
+	} else if err := services.RecordSessionAttendance(tx, session); err != nil {
+		return result, err
+	}
+
+	result.MedicationEvents, err = applySessionMedications(tx, session, input.Medications)
+	if err != nil {
+		return result, err
+	}
+
+	result.GoalProgress, err = applySessionGoalProgress(tx, session, input.GoalProgress)
+	if err != nil {
+		return result, err
+	}
+
+	result.Mentions, err = services.RecordSessionMentions(tx, session)
+	if err != nil {
+		return result, err
+	}
+
+	result.Session = session
+	result.AbnormalVitals = services.AbnormalVitals(tx, patientID, vitals)
+	return result, nil
+}
+
+// notifySession avisa al equipo de incidentes, signos vitales fuera de rango y menciones.
+func notifySession(cfg *config.Config, currentUser domains.User, result sessionResult) {
+	session := result.Session
 
-		c.JSON(http.StatusCreated, gin.H{
-			"message": "Session recorded successfully",
-			"data":    session,
-		})
+	if session.HasIncident {
+
+		go func() {
+			notifier := services.NewNotificationService(cfg)
+			notifier.NotifyIncident(session.PatientID, session.IncidentDetails)
+
+			slog.Warn("INCIDENT REPORTED - Notifications triggered",
+				"patient_id", session.PatientID,
+				"professional", currentUser.Email)
+		}()
 	}
+
+	notifySessionUpdate(cfg, currentUser, result)
+}
+
+// notifySessionUpdate avisa de lo que también puede aparecer al editar una sesión:
+// signos vitales fuera de rango y menciones nuevas.
+func notifySessionUpdate(cfg *config.Config, currentUser domains.User, result sessionResult) {
+	if len(result.AbnormalVitals) > 0 {
+		go services.NewNotificationService(cfg).NotifyAbnormalVitals(result.Session.PatientID, result.AbnormalVitals)
+	}
+
+	if len(result.Mentions) > 0 {
+		go services.NewNotificationService(cfg).NotifyMentions(result.Mentions, currentUser)
+	}
+}
+
+// respondSessionError traduce los errores del core de sesiones a la respuesta HTTP.
+func respondSessionError(c *gin.Context, err error, fallback string) {
+	var badRequest *inputError
+	if errors.As(err, &badRequest) {
+		c.JSON(badRequest.status, gin.H{"error": badRequest.Error()})
+		return
+	}
+	slog.Error(fallback, "error", err)
+	c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+}
+
+// recordSession crea la sesión, dispara las notificaciones y responde al cliente.
+// afterCreate corre dentro de la misma transacción (ej: borrar el borrador enviado).
+func recordSession(c *gin.Context, cfg *config.Config, currentUser domains.User, input domains.CreateSessionInput, afterCreate func(tx *gorm.DB) error) {
+	var result sessionResult
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		result, err = createSession(tx, currentUser, input, domains.Session{})
+		if err != nil {
+			return err
+		}
+		if afterCreate != nil {
+			return afterCreate(tx)
+		}
+		return nil
+	})
+	if err != nil {
+		respondSessionError(c, err, "Failed to save session")
+		return
+	}
+
+	notifySession(cfg, currentUser, result)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":           "Session recorded successfully",
+		"data":              result.Session,
+		"medication_events": result.MedicationEvents,
+		"goal_progress":     result.GoalProgress,
+		"abnormal_vitals":   result.AbnormalVitals,
+	})
 }
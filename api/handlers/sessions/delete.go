@@ -7,10 +7,11 @@ import (
 	"bitacora-medica-backend/api/domains"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // @Summary      Delete session
-// @Description  Soft delete a session (Only Author or Admin)
+// @Description  Soft delete a session (Only Author or Admin). Its appointment is reopened and its attendance record removed.
 // @Tags         Sessions
 // @Produce      json
 // @Param        id   path      string  true  "Session ID"
@@ -38,7 +39,20 @@ func DeleteSessionHandler() gin.HandlerFunc {
 			return
 		}
 
-		if err := db.Delete(&session).Error; err != nil {
+		// Deshace los efectos del registro: la cita vuelve a quedar abierta para
+		// registrar otra sesión y la asistencia deja de contar
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Delete(&session).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("session_id = ?", session.ID).Delete(&domains.AttendanceRecord{}).Error; err != nil {
+				return err
+			}
+			return tx.Model(&domains.Appointment{}).
+				Where("session_id = ?", session.ID).
+				Updates(map[string]interface{}{"status": domains.AppointmentScheduled, "session_id": nil}).Error
+		})
+		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete session"})
 			return
 		}
@@ -0,0 +1,277 @@
+package sessions
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"bitacora-medica-backend/api/config"
+	"bitacora-medica-backend/api/database"
+	"bitacora-medica-backend/api/domains"
+	"bitacora-medica-backend/api/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// parseDraftPayload verifica que el payload tenga la forma de CreateSessionInput
+// (sin exigir los campos obligatorios) y que el paciente, si viene, sea accesible.
+func parseDraftPayload(c *gin.Context, currentUser domains.User, payload json.RawMessage) (datatypes.JSON, *uuid.UUID, bool) {
+	var input domains.CreateSessionInput
+	if err := json.Unmarshal(payload, &input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "payload must be a session object: " + err.Error()})
+		return nil, nil, false
+	}
+
+	if input.PatientID == "" {
+		return datatypes.JSON(payload), nil, true
+	}
+
+	patientID, err := uuid.Parse(input.PatientID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Patient ID"})
+		return nil, nil, false
+	}
+	if !services.CanAccessPatient(database.GetDB(), currentUser, patientID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You do not have access to this patient"})
+		return nil, nil, false
+	}
+
+	return datatypes.JSON(payload), &patientID, true
+}
+
+// loadOwnDraft carga el borrador de :draft_id; solo su autor puede verlo.
+func loadOwnDraft(c *gin.Context, currentUser domains.User) (domains.SessionDraft, bool) {
+	var draft domains.SessionDraft
+	if err := database.GetDB().First(&draft, "id = ? AND professional_id = ?", c.Param("draft_id"), currentUser.ID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Draft not found"})
+		return draft, false
+	}
+	return draft, true
+}
+
+// @Summary      List my session drafts
+// @Description  List the current user's unsubmitted session drafts, most recently edited first
+// @Tags         Sessions
+// @Produce      json
+// @Param        patient_id  query     string  false  "Filter by patient"
+// @Success      200         {object}  map[string]interface{}
+// @Router       /sessions/drafts [get]
+// @Security     Bearer
+func ListDraftsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+
+		query := database.GetDB().Where("professional_id = ?", currentUser.ID)
+		if patientID := c.Query("patient_id"); patientID != "" {
+			query = query.Where("patient_id = ?", patientID)
+		}
+
+		var drafts []domains.SessionDraft
+		if err := query.Order("updated_at DESC").Find(&drafts).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch drafts"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"data": drafts})
+	}
+}
+
+// @Summary      Get session draft
+// @Description  Get one of the current user's session drafts
+// @Tags         Sessions
+// @Produce      json
+// @Param        draft_id  path      string  true  "Draft ID"
+// @Success      200       {object}  map[string]interface{}
+// @Failure      404       {object}  map[string]string
+// @Router       /sessions/drafts/{draft_id} [get]
+// @Security     Bearer
+func GetDraftHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+
+		draft, ok := loadOwnDraft(c, currentUser)
+		if !ok {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"data": draft})
+	}
+}
+
+// @Summary      Create session draft
+// @Description  Save a partial session. Required session fields are not validated until the draft is submitted.
+// @Tags         Sessions
+// @Accept       json
+// @Produce      json
+// @Param        input  body      domains.SaveSessionDraftInput  true  "Draft Data"
+// @Success      201    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]string
+// @Router       /sessions/drafts [post]
+// @Security     Bearer
+func CreateDraftHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+
+		var input domains.SaveSessionDraftInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		payload, patientID, ok := parseDraftPayload(c, currentUser, input.Payload)
+		if !ok {
+			return
+		}
+
+		draft := domains.SessionDraft{
+			ProfessionalID: currentUser.ID,
+			PatientID:      patientID,
+			Payload:        payload,
+			Version:        1,
+		}
+		if err := database.GetDB().Create(&draft).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save draft"})
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "Draft saved",
+			"data":    draft,
+		})
+	}
+}
+
+// @Summary      Autosave session draft
+// @Description  Replace the draft payload. If version does not match the stored one, returns 409 with the current draft.
+// @Tags         Sessions
+// @Accept       json
+// @Produce      json
+// @Param        draft_id  path      string                         true  "Draft ID"
+// @Param        input     body      domains.SaveSessionDraftInput  true  "Draft Data"
+// @Success      200       {object}  map[string]interface{}
+// @Failure      404       {object}  map[string]string
+// @Failure      409       {object}  map[string]interface{}
+// @Router       /sessions/drafts/{draft_id} [put]
+// @Security     Bearer
+func SaveDraftHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+
+		draft, ok := loadOwnDraft(c, currentUser)
+		if !ok {
+			return
+		}
+
+		var input domains.SaveSessionDraftInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		payload, patientID, ok := parseDraftPayload(c, currentUser, input.Payload)
+		if !ok {
+			return
+		}
+
+		// Update condicionado a la versión: dos dispositivos no se pisan en silencio
+		result := database.GetDB().Model(&domains.SessionDraft{}).
+			Where("id = ? AND version = ?", draft.ID, input.Version).
+			Updates(map[string]interface{}{
+				"payload":    payload,
+				"patient_id": patientID,
+				"version":    gorm.Expr("version + 1"),
+			})
+		if result.Error != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save draft"})
+			return
+		}
+		if result.RowsAffected == 0 {
+			database.GetDB().First(&draft, "id = ?", draft.ID)
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "The draft was modified elsewhere. Reload it before saving again.",
+				"data":  draft,
+			})
+			return
+		}
+
+		database.GetDB().First(&draft, "id = ?", draft.ID)
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Draft saved",
+			"data":    draft,
+		})
+	}
+}
+
+// @Summary      Discard session draft
+// @Description  Delete one of the current user's session drafts
+// @Tags         Sessions
+// @Produce      json
+// @Param        draft_id  path      string  true  "Draft ID"
+// @Success      200       {object}  map[string]interface{}
+// @Failure      404       {object}  map[string]string
+// @Router       /sessions/drafts/{draft_id} [delete]
+// @Security     Bearer
+func DeleteDraftHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+
+		draft, ok := loadOwnDraft(c, currentUser)
+		if !ok {
+			return
+		}
+
+		if err := database.GetDB().Delete(&draft).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete draft"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Draft discarded"})
+	}
+}
+
+// @Summary      Submit session draft
+// @Description  Validate the draft as a complete session and record it (same rules and notifications as POST /sessions). The draft is deleted.
+// @Tags         Sessions
+// @Produce      json
+// @Param        draft_id  path      string  true  "Draft ID"
+// @Success      201       {object}  map[string]interface{}
+// @Failure      400       {object}  map[string]string
+// @Failure      404       {object}  map[string]string
+// @Failure      409       {object}  map[string]string
+// @Router       /sessions/drafts/{draft_id}/submit [post]
+// @Security     Bearer
+func SubmitDraftHandler(cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+
+		draft, ok := loadOwnDraft(c, currentUser)
+		if !ok {
+			return
+		}
+
+		var input domains.CreateSessionInput
+		if err := json.Unmarshal(draft.Payload, &input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Draft payload is not a valid session"})
+			return
+		}
+		if err := binding.Validator.ValidateStruct(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		// Si otro envío del mismo borrador ya lo borró, se revierte esta sesión duplicada
+		recordSession(c, cfg, currentUser, input, func(tx *gorm.DB) error {
+			deleted := tx.Delete(&domains.SessionDraft{}, "id = ?", draft.ID)
+			if deleted.Error != nil {
+				return deleted.Error
+			}
+			if deleted.RowsAffected == 0 {
+				return conflictInput("Draft was already submitted")
+			}
+			return nil
+		})
+	}
+}
@@ -57,6 +57,15 @@ func GetSessionHandler(cfg *config.Config) gin.HandlerFunc {
 			}
 		}
 
-		c.JSON(http.StatusOK, gin.H{"data": session})
+		var medicationEvents []domains.SessionMedicationEvent
+		database.GetDB().Preload("Medication").
+			Where("session_id = ?", session.ID).
+			Order("created_at ASC").
+			Find(&medicationEvents)
+
+		c.JSON(http.StatusOK, gin.H{
+			"data":              session,
+			"medication_events": medicationEvents,
+		})
 	}
 }
@@ -0,0 +1,52 @@
+package sessions
+
+import (
+	"errors"
+
+	"bitacora-medica-backend/api/domains"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// applySessionGoalProgress registra el avance de la sesión sobre los objetivos activos del paciente.
+func applySessionGoalProgress(tx *gorm.DB, session domains.Session, inputs []domains.GoalProgressInput) ([]domains.GoalProgress, error) {
+	var entries []domains.GoalProgress
+
+	for _, in := range inputs {
+		if in.Score == nil && in.Note == "" {
+			return nil, badInput("Goal progress requires a score or a note")
+		}
+
+		goalID, err := uuid.Parse(in.GoalID)
+		if err != nil {
+			return nil, badInput("Invalid goal ID: " + in.GoalID)
+		}
+
+		var goal domains.TherapeuticGoal
+		err = tx.Select("id").
+			First(&goal, "id = ? AND patient_id = ? AND status = ?", goalID, session.PatientID, domains.GoalActive).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, badInput("Active goal not found for this patient: " + in.GoalID)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		sessionID := session.ID
+		entry := domains.GoalProgress{
+			GoalID:       goal.ID,
+			PatientID:    session.PatientID,
+			SessionID:    &sessionID,
+			RecordedByID: session.ProfessionalID,
+			Score:        in.Score,
+			Note:         in.Note,
+		}
+		if err := tx.Create(&entry).Error; err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
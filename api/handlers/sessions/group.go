@@ -0,0 +1,286 @@
+package sessions
+
+import (
+	"net/http"
+	"time"
+
+	"bitacora-medica-backend/api/config"
+	"bitacora-medica-backend/api/database"
+	"bitacora-medica-backend/api/domains"
+	"bitacora-medica-backend/api/pagination"
+	"bitacora-medica-backend/api/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var groupSessionsPagination = pagination.Options{
+	Sorts: map[string]pagination.SortField{
+		"created_at": {Column: "created_at", Field: "CreatedAt", IsTime: true},
+	},
+	DefaultSort:  "created_at",
+	DefaultOrder: pagination.Desc,
+}
+
+// @Summary      Create group session
+// @Description  Record one intervention for several patients. Each participant gets their own session sharing the description and plan.
+// @Tags         Sessions
+// @Accept       json
+// @Produce      json
+// @Param        input  body      domains.CreateGroupSessionInput  true  "Group Session Data"
+// @Success      201    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]string
+// @Failure      403    {object}  map[string]string
+// @Failure      404    {object}  map[string]string
+// @Failure      409    {object}  map[string]string
+// @Router       /group-sessions [post]
+// @Security     Bearer
+func CreateGroupSessionHandler(cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+
+		var input domains.CreateGroupSessionInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		db := database.GetDB()
+
+		// El acceso se valida por paciente: basta uno ajeno para rechazar el grupo
+		seen := make(map[uuid.UUID]bool)
+		for _, p := range input.Participants {
+			patientID, err := uuid.Parse(p.PatientID)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Patient ID: " + p.PatientID})
+				return
+			}
+			if seen[patientID] {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Patient " + p.PatientID + " is listed more than once"})
+				return
+			}
+			seen[patientID] = true
+			if !services.CanAccessPatient(db, currentUser, patientID) {
+				c.JSON(http.StatusForbidden, gin.H{"error": "You are not part of the care team of patient " + p.PatientID})
+				return
+			}
+		}
+
+		group := domains.GroupSession{
+			ProfessionalID:   currentUser.ID,
+			Title:            input.Title,
+			Description:      input.Description,
+			InterventionPlan: input.InterventionPlan,
+			CreatedAt:        time.Now(),
+		}
+
+		var results []sessionResult
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Omit("Sessions").Create(&group).Error; err != nil {
+				return err
+			}
+			for _, p := range input.Participants {
+				result, err := createSession(tx, currentUser, input.SessionInput(p), domains.Session{
+					GroupSessionID: &group.ID,
+					CreatedAt:      group.CreatedAt,
+				})
+				if err != nil {
+					return err
+				}
+				results = append(results, result)
+			}
+			return nil
+		})
+		if err != nil {
+			respondSessionError(c, err, "Failed to save group session")
+			return
+		}
+
+		sharedNotified := make(map[uuid.UUID]bool)
+		for _, result := range results {
+			result.Mentions = withoutRepeatedSharedMentions(result.Mentions, sharedNotified)
+			notifySession(cfg, currentUser, result)
+			group.Sessions = append(group.Sessions, result.Session)
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "Group session recorded successfully",
+			"data":    group,
+		})
+	}
+}
+
+// @Summary      List my group sessions
+// @Description  List group sessions led by the current user, newest first
+// @Tags         Sessions
+// @Produce      json
+// @Param        order   query     string  false  "asc or desc (default)"
+// @Param        limit   query     int     false  "Page size (max 100)"
+// @Param        cursor  query     string  false  "Cursor from the previous page"
+// @Success      200     {object}  map[string]interface{}
+// @Failure      400     {object}  map[string]string
+// @Router       /group-sessions [get]
+// @Security     Bearer
+func ListGroupSessionsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+
+		params, err := pagination.Parse(c, groupSessionsPagination)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		query := database.GetDB().Model(&domains.GroupSession{}).
+			Preload("Sessions", func(db *gorm.DB) *gorm.DB { return db.Select("id", "group_session_id", "patient_id") }).
+			Where("professional_id = ?", currentUser.ID)
+
+		var groups []domains.GroupSession
+		meta, err := params.Find(query, &groups)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch group sessions"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"data": groups, "meta": meta})
+	}
+}
+
+// withoutRepeatedSharedMentions descarta las menciones del texto compartido del
+// grupo (descripción y plan) ya avisadas: quedan registradas en cada sesión, pero
+// se avisa una sola vez. notified acumula los usuarios ya avisados.
+func withoutRepeatedSharedMentions(mentions []domains.Mention, notified map[uuid.UUID]bool) []domains.Mention {
+	var result []domains.Mention
+	for _, m := range mentions {
+		if m.Field == "description" || m.Field == "intervention_plan" {
+			if notified[m.MentionedUserID] {
+				continue
+			}
+			notified[m.MentionedUserID] = true
+		}
+		result = append(result, m)
+	}
+	return result
+}
+
+// loadGroupSession carga el grupo de :id con las sesiones de los pacientes a los
+// que el usuario tiene acceso. Sin ninguna, el grupo no existe para él.
+func loadGroupSession(c *gin.Context, currentUser domains.User) (domains.GroupSession, bool) {
+	db := database.GetDB()
+
+	var group domains.GroupSession
+	if err := db.First(&group, "id = ?", c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Group session not found"})
+		return group, false
+	}
+
+	query := db.Where("group_session_id = ?", group.ID).Order("created_at ASC")
+	if currentUser.Role != domains.RoleAdmin {
+		query = query.Where("patient_id IN (?)", services.AccessiblePatientIDs(db, currentUser.ID))
+	}
+	query.Find(&group.Sessions)
+
+	if len(group.Sessions) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Group session not found"})
+		return group, false
+	}
+	return group, true
+}
+
+// @Summary      Get group session
+// @Description  Get a group session with the participant sessions of the patients the user can access
+// @Tags         Sessions
+// @Produce      json
+// @Param        id   path      string  true  "Group Session ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]string
+// @Router       /group-sessions/{id} [get]
+// @Security     Bearer
+func GetGroupSessionHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+
+		group, ok := loadGroupSession(c, currentUser)
+		if !ok {
+			return
+		}
+
+		var participants int64
+		database.GetDB().Model(&domains.Session{}).Where("group_session_id = ?", group.ID).Count(&participants)
+
+		c.JSON(http.StatusOK, gin.H{"data": group, "participants": participants})
+	}
+}
+
+// @Summary      Update group session
+// @Description  Update the shared title, description and plan; the change is applied to the participant sessions of patients the user can access (Only Author or Admin)
+// @Tags         Sessions
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string                           true  "Group Session ID"
+// @Param        input  body      domains.UpdateGroupSessionInput  true  "Shared fields"
+// @Success      200    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]string
+// @Failure      403    {object}  map[string]string
+// @Failure      404    {object}  map[string]string
+// @Router       /group-sessions/{id} [put]
+// @Security     Bearer
+func UpdateGroupSessionHandler(cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+
+		var input domains.UpdateGroupSessionInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		group, ok := loadGroupSession(c, currentUser)
+		if !ok {
+			return
+		}
+		if group.ProfessionalID != currentUser.ID && currentUser.Role != domains.RoleAdmin {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You can only edit your own group sessions"})
+			return
+		}
+
+		group.Title = input.Title
+		group.Description = input.Description
+		group.InterventionPlan = input.InterventionPlan
+
+		// Solo se editan las sesiones que cargó loadGroupSession (pacientes accesibles)
+		var mentions []domains.Mention
+		err := database.GetDB().Transaction(func(tx *gorm.DB) error {
+			if err := tx.Omit("Sessions").Save(&group).Error; err != nil {
+				return err
+			}
+			for i := range group.Sessions {
+				session := &group.Sessions[i]
+				session.Description = input.Description
+				session.InterventionPlan = input.InterventionPlan
+				markSessionEdited(session)
+				if err := tx.Save(session).Error; err != nil {
+					return err
+				}
+				created, err := services.RecordSessionMentions(tx, *session)
+				if err != nil {
+					return err
+				}
+				mentions = append(mentions, created...)
+			}
+			return nil
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update group session"})
+			return
+		}
+
+		mentions = withoutRepeatedSharedMentions(mentions, make(map[uuid.UUID]bool))
+		if len(mentions) > 0 {
+			go services.NewNotificationService(cfg).NotifyMentions(mentions, currentUser)
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Group session updated", "data": group})
+	}
+}
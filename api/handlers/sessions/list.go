@@ -1,35 +1,76 @@
 package sessions
 
 import (
-	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"bitacora-medica-backend/api/config"
 	"bitacora-medica-backend/api/database"
 	"bitacora-medica-backend/api/domains"
+	"bitacora-medica-backend/api/pagination"
 	"bitacora-medica-backend/api/services"
+	"bitacora-medica-backend/api/utils"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
+var sessionsPagination = pagination.Options{
+	Sorts: map[string]pagination.SortField{
+		"created_at": {Column: "created_at", Field: "CreatedAt", IsTime: true},
+		"updated_at": {Column: "updated_at", Field: "UpdatedAt", IsTime: true},
+	},
+	DefaultSort:  "created_at",
+	DefaultOrder: pagination.Desc,
+}
+
 // ListSessionsHandler obtiene sesiones con filtros
 // @Summary      List sessions
-// @Description  List sessions with optional filters
+// @Description  List sessions with optional filters. Each item carries the author's display name instead of the full user.
 // @Tags         Sessions
 // @Produce      json
 // @Param        patient_id       query     string  false  "Filter by Patient ID"
 // @Param        professional_id  query     string  false  "Filter by Professional ID"
 // @Param        has_incident     query     boolean false  "Filter by Incident presence"
+// @Param        group_session_id query     string  false  "Filter by group session"
+// @Param        has_photos       query     boolean false  "Filter by photo presence"
+// @Param        from             query     string  false  "Created on or after (YYYY-MM-DD)"
+// @Param        to               query     string  false  "Created on or before (YYYY-MM-DD)"
+// @Param        q                query     string  false  "Full-text search (Spanish) over description, plan, achievements and next session notes"
+// @Param        cosign_status    query     string  false  "NOT_REQUIRED, PENDING, APPROVED or RETURNED"
+// @Param        tags             query     string  false  "Comma-separated tag IDs of the patient"
+// @Param        sort             query     string  false  "created_at (default) or updated_at"
+// @Param        order            query     string  false  "asc or desc (default)"
+// @Param        limit            query     int     false  "Page size (max 100)"
+// @Param        cursor           query     string  false  "Cursor from the previous page"
 // @Success      200              {object}  map[string]interface{}
+// @Failure      400              {object}  map[string]string
 // @Failure      500              {object}  map[string]string
 // @Router       /sessions [get]
 // @Security     Bearer
 func ListSessionsHandler(cfg *config.Config) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
 		db := database.GetDB()
 		var sessions []domains.Session
 
-		query := db.Model(&domains.Session{}).Preload("Creator")
+		params, err := pagination.Parse(c, sessionsPagination)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		query := db.Model(&domains.Session{}).Preload("Creator", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "email", "profile_data")
+		})
+
+		// Solo sesiones de pacientes del equipo del usuario (los filtros y la búsqueda
+		// de texto no deben exponer notas de otros equipos)
+		if currentUser.Role != domains.RoleAdmin {
+			query = query.Where("patient_id IN (?)", services.AccessiblePatientIDs(db, currentUser.ID))
+		}
 
 		patientID := c.Query("patient_id")
 		if patientID != "" {
@@ -41,25 +82,72 @@ func ListSessionsHandler(cfg *config.Config) gin.HandlerFunc {
 			query = query.Where("professional_id = ?", profID)
 		}
 
-		incident := c.Query("has_incident")
-		if incident == "true" {
-			query = query.Where("has_incident = ?", true)
+		if incident := c.Query("has_incident"); incident != "" {
+			hasIncident, err := strconv.ParseBool(incident)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "has_incident must be true or false"})
+				return
+			}
+			query = query.Where("has_incident = ?", hasIncident)
 		}
 
-		page := 1
-		limit := 10
-		if c.Query("page") != "" {
-			fmt.Sscan(c.Query("page"), &page)
+		if groupID := c.Query("group_session_id"); groupID != "" {
+			query = query.Where("group_session_id = ?", groupID)
 		}
-		if c.Query("limit") != "" {
-			fmt.Sscan(c.Query("limit"), &limit)
+
+		if photos := c.Query("has_photos"); photos != "" {
+			hasPhotos, err := strconv.ParseBool(photos)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "has_photos must be true or false"})
+				return
+			}
+			if hasPhotos {
+				query = query.Where("cardinality(photos) > 0")
+			} else {
+				query = query.Where("photos IS NULL OR cardinality(photos) = 0")
+			}
 		}
-		offset := (page - 1) * limit
 
-		var total int64
-		query.Count(&total)
+		if from := c.Query("from"); from != "" {
+			fromDate, err := time.Parse("2006-01-02", from)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from date format (YYYY-MM-DD)"})
+				return
+			}
+			query = query.Where("created_at >= ?", fromDate)
+		}
+		if to := c.Query("to"); to != "" {
+			toDate, err := time.Parse("2006-01-02", to)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to date format (YYYY-MM-DD)"})
+				return
+			}
+			query = query.Where("created_at < ?", toDate.AddDate(0, 0, 1))
+		}
+
+		if search := strings.TrimSpace(c.Query("q")); search != "" {
+			query = query.Where(domains.SessionSearchVectorSQL+" @@ websearch_to_tsquery('spanish', ?)", search)
+		}
+
+		if cosignStatus := c.Query("cosign_status"); cosignStatus != "" {
+			if !domains.CosignStatus(cosignStatus).IsValid() {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cosign_status. Options: NOT_REQUIRED, PENDING, APPROVED, RETURNED"})
+				return
+			}
+			query = query.Where("cosign_status = ?", cosignStatus)
+		}
 
-		if err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&sessions).Error; err != nil {
+		if tags := c.Query("tags"); tags != "" {
+			tagIDs, err := utils.ParseUUIDs(strings.Split(tags, ","))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			query = query.Where("patient_id IN (?)", services.TaggedPatientIDs(db, tagIDs))
+		}
+
+		meta, err := params.Find(query, &sessions)
+		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch sessions"})
 			return
 		}
@@ -95,14 +183,17 @@ func ListSessionsHandler(cfg *config.Config) gin.HandlerFunc {
 			}
 		}
 
+		items := make([]domains.SessionListItem, len(sessions))
+		for i, session := range sessions {
+			items[i] = domains.SessionListItem{
+				Session:    session,
+				AuthorName: services.UserDisplayName(session.Creator),
+			}
+		}
+
 		c.JSON(http.StatusOK, gin.H{
-			"data": sessions,
-			"meta": gin.H{
-				"total":     total,
-				"page":      page,
-				"limit":     limit,
-				"last_page": (int(total) + limit - 1) / limit,
-			},
+			"data": items,
+			"meta": meta,
 		})
 	}
 }
@@ -0,0 +1,119 @@
+package sessions
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"bitacora-medica-backend/api/domains"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// inputError marca los errores de validación detectados dentro de una transacción,
+// para responder 4xx en lugar de 500.
+type inputError struct {
+	status int
+	msg    string
+}
+
+func (e *inputError) Error() string { return e.msg }
+
+func badInput(msg string) error { return &inputError{status: http.StatusBadRequest, msg: msg} }
+
+func notFoundInput(msg string) error { return &inputError{status: http.StatusNotFound, msg: msg} }
+
+func conflictInput(msg string) error { return &inputError{status: http.StatusConflict, msg: msg} }
+
+func forbiddenInput(msg string) error { return &inputError{status: http.StatusForbidden, msg: msg} }
+
+// applySessionMedications registra los eventos de medicación de la sesión y
+// actualiza la lista de medicamentos del paciente.
+func applySessionMedications(tx *gorm.DB, session domains.Session, inputs []domains.SessionMedicationInput) ([]domains.SessionMedicationEvent, error) {
+	var events []domains.SessionMedicationEvent
+
+	for _, in := range inputs {
+		action := domains.MedicationAction(in.Action)
+
+		var medication domains.PatientMedication
+		if action == domains.MedicationStarted {
+			if in.DrugName == "" || in.Dose == "" || in.Route == "" || in.Frequency == "" {
+				return nil, badInput("drug_name, dose, route and frequency are required to start a medication")
+			}
+			medication = domains.PatientMedication{
+				PatientID:    session.PatientID,
+				DrugName:     in.DrugName,
+				Dose:         in.Dose,
+				Route:        domains.MedicationRoute(in.Route),
+				Frequency:    in.Frequency,
+				StartDate:    time.Now(),
+				Status:       domains.MedicationActive,
+				Notes:        in.Notes,
+				RecordedByID: session.ProfessionalID,
+			}
+			if err := tx.Create(&medication).Error; err != nil {
+				return nil, err
+			}
+		} else {
+			medicationID, err := uuid.Parse(in.MedicationID)
+			if err != nil {
+				return nil, badInput("medication_id is required for action " + in.Action)
+			}
+			err = tx.First(&medication, "id = ? AND patient_id = ? AND status = ?",
+				medicationID, session.PatientID, domains.MedicationActive).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, badInput("Active medication not found for this patient: " + in.MedicationID)
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			switch action {
+			case domains.MedicationChanged:
+				if in.Dose != "" {
+					medication.Dose = in.Dose
+				}
+				if in.Route != "" {
+					medication.Route = domains.MedicationRoute(in.Route)
+				}
+				if in.Frequency != "" {
+					medication.Frequency = in.Frequency
+				}
+			case domains.MedicationStoppedEvent:
+				now := time.Now()
+				medication.Status = domains.MedicationStopped
+				medication.EndDate = &now
+				medication.StopReason = in.Notes
+			}
+
+			if action != domains.MedicationAdministered {
+				if err := tx.Save(&medication).Error; err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		dose := in.Dose
+		if dose == "" {
+			dose = medication.Dose
+		}
+
+		event := domains.SessionMedicationEvent{
+			SessionID:    session.ID,
+			PatientID:    session.PatientID,
+			MedicationID: medication.ID,
+			Action:       action,
+			Dose:         dose,
+			Notes:        in.Notes,
+		}
+		if err := tx.Create(&event).Error; err != nil {
+			return nil, err
+		}
+
+		event.Medication = medication
+		events = append(events, event)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,271 @@
+package sessions
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"bitacora-medica-backend/api/config"
+	"bitacora-medica-backend/api/database"
+	"bitacora-medica-backend/api/domains"
+	"bitacora-medica-backend/api/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// maxClockSkew tolera relojes de dispositivos levemente adelantados.
+const maxClockSkew = 5 * time.Minute
+
+// syncErrorMessage expone los errores de validación del core; el resto se loguea.
+func syncErrorMessage(err error) string {
+	var badRequest *inputError
+	if errors.As(err, &badRequest) {
+		return badRequest.Error()
+	}
+	slog.Error("Sync item failed", "error", err)
+	return "Failed to save item"
+}
+
+// @Summary      Offline batch sync
+// @Description  Apply sessions and documents created offline. Each item carries a client_id: retries return DUPLICATE instead of creating twice. Session edits are applied only if base_version matches the server version, otherwise CONFLICT with the server copy. Files must be uploaded first to /uploads/image or /uploads/document.
+// @Tags         Sessions
+// @Accept       json
+// @Produce      json
+// @Param        input  body      domains.SyncBatchInput  true  "Batch"
+// @Success      200    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]string
+// @Router       /sync [post]
+// @Security     Bearer
+func SyncBatchHandler(cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+
+		var input domains.SyncBatchInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		total := len(input.Sessions) + len(input.Documents)
+		if total == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "The batch is empty"})
+			return
+		}
+		if total > domains.MaxSyncBatchItems {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "A batch cannot have more than 100 items"})
+			return
+		}
+
+		results := make([]domains.SyncItemResult, 0, total)
+		// Primero los documentos: las sesiones del lote pueden referenciarlos
+		for _, item := range input.Documents {
+			results = append(results, syncDocument(currentUser, item))
+		}
+		for _, item := range input.Sessions {
+			results = append(results, syncSession(cfg, currentUser, item))
+		}
+
+		summary := make(map[domains.SyncStatus]int)
+		for _, r := range results {
+			summary[r.Status]++
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"data":    results,
+			"summary": summary,
+		})
+	}
+}
+
+func syncSession(cfg *config.Config, currentUser domains.User, item domains.SyncSessionItem) domains.SyncItemResult {
+	result := domains.SyncItemResult{ClientID: item.ClientID, Kind: domains.SyncKindSession}
+	fail := func(msg string) domains.SyncItemResult {
+		result.Status = domains.SyncError
+		result.Error = msg
+		return result
+	}
+
+	if err := binding.Validator.ValidateStruct(&item); err != nil {
+		return fail(err.Error())
+	}
+
+	clientID, _ := uuid.Parse(item.ClientID)
+	sessionID, _ := uuid.Parse(item.SessionID)
+	result.EntityID = &sessionID
+	db := database.GetDB()
+
+	if receipt, found := services.FindSyncReceipt(db, currentUser.ID, clientID); found {
+		result.Status = domains.SyncDuplicate
+		result.Version = receipt.Version
+		return result
+	}
+
+	patientID, err := uuid.Parse(item.Data.PatientID)
+	if err != nil || !services.CanAccessPatient(db, currentUser, patientID) {
+		return fail("You do not have access to this patient")
+	}
+
+	var existing domains.Session
+	err = db.Unscoped().Where("id = ?", sessionID).Limit(1).Find(&existing).Error
+	if err != nil {
+		return fail(syncErrorMessage(err))
+	}
+
+	// Sesión nueva creada offline
+	if existing.ID == uuid.Nil {
+		if item.BaseVersion != 0 {
+			result.Status = domains.SyncConflict
+			result.Error = "The session no longer exists on the server"
+			return result
+		}
+
+		session := domains.Session{ID: sessionID}
+		if item.RecordedAt != nil {
+			if item.RecordedAt.After(time.Now().Add(maxClockSkew)) {
+				return fail("recorded_at cannot be in the future")
+			}
+			session.CreatedAt = *item.RecordedAt
+		}
+
+		var created sessionResult
+		err := db.Transaction(func(tx *gorm.DB) error {
+			var err error
+			created, err = createSession(tx, currentUser, item.Data, session)
+			if err != nil {
+				return err
+			}
+			return tx.Create(&domains.SyncReceipt{
+				ClientID: clientID,
+				UserID:   currentUser.ID,
+				Kind:     domains.SyncKindSession,
+				EntityID: &sessionID,
+				Status:   domains.SyncCreated,
+				Version:  created.Session.Version,
+			}).Error
+		})
+		if err != nil {
+			return fail(syncErrorMessage(err))
+		}
+
+		notifySession(cfg, currentUser, created)
+		result.Status = domains.SyncCreated
+		result.Version = created.Session.Version
+		return result
+	}
+
+	// Edición offline de una sesión existente
+	if existing.ProfessionalID != currentUser.ID || existing.PatientID != patientID {
+		return fail("You can only edit your own sessions")
+	}
+	if existing.DeletedAt.Valid {
+		result.Status = domains.SyncConflict
+		result.Error = "The session was deleted on the server"
+		return result
+	}
+	if item.BaseVersion != existing.Version {
+		result.Status = domains.SyncConflict
+		result.Version = existing.Version
+		result.Server = existing
+		return result
+	}
+
+	var updated sessionResult
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		updated, err = updateSession(tx, &existing, item.Data)
+		if err != nil {
+			return err
+		}
+		return tx.Create(&domains.SyncReceipt{
+			ClientID: clientID,
+			UserID:   currentUser.ID,
+			Kind:     domains.SyncKindSession,
+			EntityID: &sessionID,
+			Status:   domains.SyncUpdated,
+			Version:  existing.Version,
+		}).Error
+	})
+	if err != nil {
+		return fail(syncErrorMessage(err))
+	}
+
+	notifySessionUpdate(cfg, currentUser, updated)
+
+	result.Status = domains.SyncUpdated
+	result.Version = existing.Version
+	return result
+}
+
+func syncDocument(currentUser domains.User, item domains.SyncDocumentItem) domains.SyncItemResult {
+	result := domains.SyncItemResult{ClientID: item.ClientID, Kind: domains.SyncKindDocument}
+	fail := func(msg string) domains.SyncItemResult {
+		result.Status = domains.SyncError
+		result.Error = msg
+		return result
+	}
+
+	if err := binding.Validator.ValidateStruct(&item); err != nil {
+		return fail(err.Error())
+	}
+
+	clientID, _ := uuid.Parse(item.ClientID)
+	documentID, _ := uuid.Parse(item.DocumentID)
+	patientID, _ := uuid.Parse(item.PatientID)
+	result.EntityID = &documentID
+	db := database.GetDB()
+
+	if _, found := services.FindSyncReceipt(db, currentUser.ID, clientID); found {
+		result.Status = domains.SyncDuplicate
+		return result
+	}
+
+	if !services.CanAccessPatient(db, currentUser, patientID) {
+		return fail("You do not have access to this patient")
+	}
+
+	// El archivo debe haberse subido para este mismo paciente
+	if !strings.HasPrefix(item.FilePath, patientID.String()+"/") || strings.Contains(item.FilePath, "..") {
+		return fail("file_path does not belong to this patient")
+	}
+
+	docDate, err := time.Parse("2006-01-02", item.Date)
+	if err != nil {
+		return fail("Invalid date format (YYYY-MM-DD)")
+	}
+
+	doc := domains.PatientDocument{
+		ID:          documentID,
+		PatientID:   patientID,
+		Name:        item.Name,
+		Category:    domains.DocumentCategory(item.Category),
+		Date:        docDate,
+		FileUrl:     item.FilePath,
+		FileType:    strings.TrimPrefix(filepath.Ext(item.FilePath), "."),
+		Description: item.Description,
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&doc).Error; err != nil {
+			return err
+		}
+		return tx.Create(&domains.SyncReceipt{
+			ClientID: clientID,
+			UserID:   currentUser.ID,
+			Kind:     domains.SyncKindDocument,
+			EntityID: &documentID,
+			Status:   domains.SyncCreated,
+		}).Error
+	})
+	if err != nil {
+		return fail(syncErrorMessage(err))
+	}
+
+	result.Status = domains.SyncCreated
+	return result
+}
@@ -4,12 +4,14 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"bitacora-medica-backend/api/config"
 	"bitacora-medica-backend/api/database"
 	"bitacora-medica-backend/api/domains"
+	"bitacora-medica-backend/api/services"
 
 	"github.com/gin-gonic/gin"
 	"github.com/lib/pq"
-	"gorm.io/datatypes"
+	"gorm.io/gorm"
 )
 
 // UpdateSessionHandler permite editar una sesión (Solo el autor)
@@ -27,7 +29,7 @@ import (
 // @Failure      500    {object}  map[string]string
 // @Router       /sessions/{id} [put]
 // @Security     Bearer
-func UpdateSessionHandler() gin.HandlerFunc {
+func UpdateSessionHandler(cfg *config.Config) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id := c.Param("id")
 		currentUser := c.MustGet("currentUser").(domains.User)
@@ -50,26 +52,97 @@ func UpdateSessionHandler() gin.HandlerFunc {
 			return
 		}
 
-		if input.Vitals != nil {
-			vitalsJSON, _ := json.Marshal(input.Vitals)
-			session.Vitals = datatypes.JSON(vitalsJSON)
+		result, err := updateSession(db, &session, input)
+		if err != nil {
+			respondSessionError(c, err, "Failed to update session")
+			return
 		}
 
-		session.InterventionPlan = input.InterventionPlan
-		session.Description = input.Description
-		session.Achievements = input.Achievements
-		session.PatientPerformance = input.PatientPerformance
-		session.NextSessionNotes = input.NextSessionNotes
-		session.HasIncident = input.HasIncident
-		session.IncidentDetails = input.IncidentDetails
-		session.IncidentPhoto = input.IncidentPhoto
-		session.Photos = pq.StringArray(input.Photos)
-
-		if err := db.Save(&session).Error; err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update session"})
-			return
+		notifySessionUpdate(cfg, currentUser, result)
+
+		c.JSON(http.StatusOK, gin.H{"message": "Session updated", "data": session, "abnormal_vitals": result.AbnormalVitals})
+	}
+}
+
+// markSessionEdited sube la versión de la sesión y, si es supervisada, la devuelve
+// a la cola del supervisor: toda edición requiere una nueva co-firma.
+func markSessionEdited(session *domains.Session) {
+	session.Version++
+	if session.CosignStatus != domains.CosignNotRequired {
+		session.CosignStatus = domains.CosignPending
+		session.CosignedByID = nil
+		session.CosignedAt = nil
+	}
+}
+
+// updateSession aplica el input sobre la sesión, la guarda y sube su versión.
+// Devuelve los signos vitales fuera de umbral (si se enviaron) y las menciones nuevas.
+func updateSession(db *gorm.DB, session *domains.Session, input domains.CreateSessionInput) (sessionResult, error) {
+	var result sessionResult
+
+	if session.TemplateID != nil {
+		var template domains.SessionTemplate
+		if err := db.Unscoped().First(&template, "id = ?", session.TemplateID).Error; err == nil {
+			// Sin extra_fields se conservan los guardados
+			if input.ExtraFields == nil && len(session.ExtraFields) > 0 {
+				_ = json.Unmarshal(session.ExtraFields, &input.ExtraFields)
+			}
+			extraFields, err := services.ApplySessionTemplate(template, &input)
+			if err != nil {
+				return result, badInput(err.Error())
+			}
+			session.ExtraFields = extraFields
 		}
+	} else if len(input.ExtraFields) > 0 {
+		return result, badInput("extra_fields require a session recorded with a template")
+	}
 
-		c.JSON(http.StatusOK, gin.H{"message": "Session updated", "data": session})
+	if input.InterventionPlan == "" {
+		return result, badInput("intervention_plan is required")
+	}
+
+	if input.HasIncident && input.IncidentDetails == "" {
+		return result, badInput("Incident details are mandatory when an incident is reported.")
+	}
+
+	if input.Vitals != nil {
+		vitalsJSON, vitals, err := parseVitals(input.Vitals)
+		if err != nil {
+			return result, badInput(err.Error())
+		}
+		session.Vitals = vitalsJSON
+		result.AbnormalVitals = services.AbnormalVitals(db, session.PatientID, vitals)
+	}
+
+	session.InterventionPlan = input.InterventionPlan
+	session.Description = input.Description
+	session.Achievements = input.Achievements
+	session.PatientPerformance = input.PatientPerformance
+	session.NextSessionNotes = input.NextSessionNotes
+	session.HasIncident = input.HasIncident
+	session.IncidentDetails = input.IncidentDetails
+	session.IncidentPhoto = input.IncidentPhoto
+	session.Photos = pq.StringArray(input.Photos)
+	markSessionEdited(session)
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(session).Error; err != nil {
+			return err
+		}
+		// Un incidente reportado al editar abre su seguimiento; quitar la marca no
+		// borra el incidente ya creado
+		if session.HasIncident {
+			if err := services.OpenSessionIncident(tx, *session, input.IncidentSeverity, input.IncidentCategory); err != nil {
+				return err
+			}
+		}
+		var err error
+		result.Mentions, err = services.RecordSessionMentions(tx, *session)
+		return err
+	})
+	if err != nil {
+		return result, err
 	}
+	result.Session = *session
+	return result, nil
 }
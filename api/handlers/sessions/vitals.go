@@ -0,0 +1,38 @@
+package sessions
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"bitacora-medica-backend/api/domains"
+	"bitacora-medica-backend/api/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/datatypes"
+)
+
+// parseVitals valida los signos vitales del input y los serializa normalizados.
+func parseVitals(raw map[string]interface{}) (datatypes.JSON, map[domains.VitalKey]float64, error) {
+	vitals, err := services.NormalizeVitals(raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	vitalsJSON, err := json.Marshal(vitals)
+	if err != nil {
+		return nil, nil, err
+	}
+	return datatypes.JSON(vitalsJSON), vitals, nil
+}
+
+// @Summary      Vitals catalog
+// @Description  Supported vital signs with units and plausible ranges. blood_pressure may also be sent as "120/80".
+// @Tags         Sessions
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Router       /vitals/catalog [get]
+// @Security     Bearer
+func GetVitalsCatalogHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"data": domains.VitalsCatalog})
+	}
+}
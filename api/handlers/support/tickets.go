@@ -6,6 +6,7 @@ import (
 	"bitacora-medica-backend/api/config"
 	"bitacora-medica-backend/api/database"
 	"bitacora-medica-backend/api/domains"
+	"bitacora-medica-backend/api/pagination"
 	"bitacora-medica-backend/api/services"
 
 	"github.com/gin-gonic/gin"
@@ -52,13 +53,30 @@ func CreateTicketHandler(cfg *config.Config) gin.HandlerFunc {
 	}
 }
 
+var ticketsPagination = pagination.Options{
+	Sorts: map[string]pagination.SortField{
+		"created_at": {Column: "created_at", Field: "CreatedAt", IsTime: true},
+		"updated_at": {Column: "updated_at", Field: "UpdatedAt", IsTime: true},
+		// Abiertos primero (OPEN > CLOSED) y por fecha de creación dentro de cada estado
+		"status": {Column: "created_at", Field: "CreatedAt", IsTime: true, GroupColumn: "status", GroupField: "Status"},
+	},
+	DefaultSort:  "created_at",
+	DefaultOrder: pagination.Desc,
+}
+
 // ListTicketsHandler (Dual: Admin ve todo, Usuario ve lo suyo)
 // Este NO requiere cambios porque no envía correos
 // @Summary      List support tickets
 // @Description  List tickets (Admin sees all, User sees own)
 // @Tags         Support
 // @Produce      json
+// @Param        status  query     string  false  "OPEN or CLOSED"
+// @Param        sort    query     string  false  "created_at (default), updated_at or status (open first, default for admins)"
+// @Param        order   query     string  false  "asc or desc (default desc, asc for admins)"
+// @Param        limit   query     int     false  "Page size (max 100)"
+// @Param        cursor  query     string  false  "Cursor from the previous page"
 // @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
 // @Router       /support [get]
 // @Security     Bearer
 func ListTicketsHandler() gin.HandlerFunc {
@@ -67,13 +85,37 @@ func ListTicketsHandler() gin.HandlerFunc {
 		db := database.GetDB()
 		var tickets []domains.SupportTicket
 
+		// El admin atiende primero los tickets abiertos más antiguos; el usuario ve primero los suyos más recientes
+		options := ticketsPagination
+		query := db.Where("user_id = ?", currentUser.ID)
 		if currentUser.Role == domains.RoleAdmin {
-			db.Preload("User").Order("status DESC, created_at ASC").Find(&tickets)
-		} else {
-			db.Where("user_id = ?", currentUser.ID).Order("created_at DESC").Find(&tickets)
+			options.DefaultSort = "status"
+			options.DefaultOrder = pagination.Asc
+			query = db.Preload("User")
+		}
+
+		params, err := pagination.Parse(c, options)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		switch status := c.Query("status"); status {
+		case "":
+		case string(domains.TicketOpen), string(domains.TicketClosed):
+			query = query.Where("status = ?", status)
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status. Options: OPEN, CLOSED"})
+			return
+		}
+
+		meta, err := params.Find(query, &tickets)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch tickets"})
+			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{"data": tickets})
+		c.JSON(http.StatusOK, gin.H{"data": tickets, "meta": meta})
 	}
 }
 
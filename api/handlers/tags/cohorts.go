@@ -0,0 +1,144 @@
+package tags
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"bitacora-medica-backend/api/database"
+	"bitacora-medica-backend/api/domains"
+	"bitacora-medica-backend/api/services"
+	"bitacora-medica-backend/api/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// @Summary      List saved cohorts
+// @Description  List the user's saved cohorts and those shared with their organizations
+// @Tags         Tags
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Router       /cohorts [get]
+// @Security     Bearer
+func ListCohortsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+		db := database.GetDB()
+
+		var cohorts []domains.SavedCohort
+		if err := services.VisibleCohorts(db, currentUser.ID).Order("name ASC").Find(&cohorts).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch cohorts"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"data": cohorts})
+	}
+}
+
+// @Summary      Save cohort
+// @Description  Save a named patient filter preset (status and tags) usable in patient lists and dashboards
+// @Tags         Tags
+// @Accept       json
+// @Produce      json
+// @Param        input  body      domains.CreateCohortInput  true  "Cohort Data"
+// @Success      201    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]string
+// @Failure      403    {object}  map[string]string
+// @Router       /cohorts [post]
+// @Security     Bearer
+func CreateCohortHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+
+		var input domains.CreateCohortInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		db := database.GetDB()
+
+		if input.Filters.Status != "" && !domains.PatientStatus(input.Filters.Status).IsValid() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status. Options: ACTIVE, ON_HOLD, DISCHARGED, DECEASED"})
+			return
+		}
+
+		if len(input.Filters.TagIDs) > 0 {
+			tagIDs, err := utils.ParseUUIDs(input.Filters.TagIDs)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			var visible int64
+			services.VisibleTags(db, currentUser.ID).Where("id IN ?", tagIDs).Count(&visible)
+			if int(visible) != len(tagIDs) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "One or more tags do not exist or are not visible to you"})
+				return
+			}
+		}
+
+		filters, err := json.Marshal(input.Filters)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process filters"})
+			return
+		}
+
+		cohort := domains.SavedCohort{
+			Name:    input.Name,
+			OwnerID: currentUser.ID,
+			Filters: filters,
+		}
+
+		if input.OrganizationID != "" {
+			orgID, err := uuid.Parse(input.OrganizationID)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID"})
+				return
+			}
+			if !services.IsOrganizationMember(db, currentUser.ID, orgID) {
+				c.JSON(http.StatusForbidden, gin.H{"error": "You are not a member of this organization"})
+				return
+			}
+			cohort.OrganizationID = &orgID
+		}
+
+		if err := db.Create(&cohort).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save cohort"})
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "Cohort saved successfully",
+			"data":    cohort,
+		})
+	}
+}
+
+// @Summary      Delete saved cohort
+// @Description  Delete a saved cohort (owner only)
+// @Tags         Tags
+// @Produce      json
+// @Param        id   path      string  true  "Cohort ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]string
+// @Router       /cohorts/{id} [delete]
+// @Security     Bearer
+func DeleteCohortHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+		db := database.GetDB()
+
+		var cohort domains.SavedCohort
+		if err := db.First(&cohort, "id = ? AND owner_id = ?", c.Param("id"), currentUser.ID).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Cohort not found"})
+			return
+		}
+
+		if err := db.Delete(&cohort).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete cohort"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Cohort deleted successfully"})
+	}
+}
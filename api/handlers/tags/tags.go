@@ -0,0 +1,132 @@
+package tags
+
+import (
+	"net/http"
+
+	"bitacora-medica-backend/api/database"
+	"bitacora-medica-backend/api/domains"
+	"bitacora-medica-backend/api/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// @Summary      List tags
+// @Description  List personal tags and tags of the user's organizations
+// @Tags         Tags
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Router       /tags [get]
+// @Security     Bearer
+func ListTagsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+		db := database.GetDB()
+
+		var tags []domains.Tag
+		if err := services.VisibleTags(db, currentUser.ID).Order("scope ASC, name ASC").Find(&tags).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch tags"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"data": tags})
+	}
+}
+
+// @Summary      Create tag
+// @Description  Create a personal tag, or an organization tag when organization_id is sent
+// @Tags         Tags
+// @Accept       json
+// @Produce      json
+// @Param        input  body      domains.CreateTagInput  true  "Tag Data"
+// @Success      201    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]string
+// @Failure      403    {object}  map[string]string
+// @Router       /tags [post]
+// @Security     Bearer
+func CreateTagHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+
+		var input domains.CreateTagInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		db := database.GetDB()
+		tag := domains.Tag{
+			Name:    input.Name,
+			Color:   input.Color,
+			Scope:   domains.TagScopeUser,
+			OwnerID: currentUser.ID,
+		}
+
+		if input.OrganizationID != "" {
+			orgID, err := uuid.Parse(input.OrganizationID)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID"})
+				return
+			}
+			if !services.IsOrganizationMember(db, currentUser.ID, orgID) {
+				c.JSON(http.StatusForbidden, gin.H{"error": "You are not a member of this organization"})
+				return
+			}
+			tag.Scope = domains.TagScopeOrganization
+			tag.OrganizationID = &orgID
+		}
+
+		if err := db.Create(&tag).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save tag"})
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "Tag created successfully",
+			"data":    tag,
+		})
+	}
+}
+
+// @Summary      Delete tag
+// @Description  Delete a tag and detach it from every patient. Organization tags can be deleted by their creator or an organization manager.
+// @Tags         Tags
+// @Produce      json
+// @Param        id   path      string  true  "Tag ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Router       /tags/{id} [delete]
+// @Security     Bearer
+func DeleteTagHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+		db := database.GetDB()
+
+		var tag domains.Tag
+		if err := services.VisibleTags(db, currentUser.ID).First(&tag, "id = ?", c.Param("id")).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Tag not found"})
+			return
+		}
+
+		canDelete := tag.OwnerID == currentUser.ID
+		if !canDelete && tag.OrganizationID != nil {
+			canDelete = services.IsOrganizationMember(db, currentUser.ID, *tag.OrganizationID, domains.OrgManager)
+		}
+		if !canDelete {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You cannot delete this tag"})
+			return
+		}
+
+		if err := db.Where("tag_id = ?", tag.ID).Delete(&domains.PatientTag{}).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete tag"})
+			return
+		}
+		if err := db.Delete(&tag).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete tag"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Tag deleted successfully"})
+	}
+}
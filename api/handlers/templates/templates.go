@@ -0,0 +1,229 @@
+package templates
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"bitacora-medica-backend/api/database"
+	"bitacora-medica-backend/api/domains"
+	"bitacora-medica-backend/api/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// applyTemplateInput valida el input y lo vuelca en la plantilla. Responde el error si falla.
+func applyTemplateInput(c *gin.Context, currentUser domains.User, input domains.SessionTemplateInput, template *domains.SessionTemplate) bool {
+	if err := services.ValidateTemplateFields(input.ExtraFields); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return false
+	}
+
+	template.OrganizationID = nil
+	if input.OrganizationID != "" {
+		orgID, err := uuid.Parse(input.OrganizationID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID"})
+			return false
+		}
+		if !services.IsOrganizationMember(database.GetDB(), currentUser.ID, orgID) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You are not a member of this organization"})
+			return false
+		}
+		template.OrganizationID = &orgID
+	}
+
+	sections, _ := json.Marshal(input.Sections)
+	extraFields, _ := json.Marshal(input.ExtraFields)
+
+	template.Name = input.Name
+	template.Specialty = input.Specialty
+	template.Description = input.Description
+	template.Sections = sections
+	template.DefaultInterventionPlan = input.DefaultInterventionPlan
+	template.RequiredFields = pq.StringArray(input.RequiredFields)
+	template.ExtraFields = extraFields
+	return true
+}
+
+// loadEditableTemplate carga la plantilla de :id si el usuario es su dueño o
+// gestor de la organización a la que pertenece.
+func loadEditableTemplate(c *gin.Context, currentUser domains.User) (domains.SessionTemplate, bool) {
+	db := database.GetDB()
+
+	var template domains.SessionTemplate
+	if err := services.VisibleSessionTemplates(db, currentUser.ID).First(&template, "id = ?", c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Template not found"})
+		return template, false
+	}
+
+	canEdit := template.OwnerID == currentUser.ID ||
+		(template.OrganizationID != nil && services.IsOrganizationMember(db, currentUser.ID, *template.OrganizationID, domains.OrgManager))
+	if !canEdit {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the owner or an organization manager can modify this template"})
+		return template, false
+	}
+
+	return template, true
+}
+
+// @Summary      List session templates
+// @Description  List personal session templates and those of the user's organizations
+// @Tags         Templates
+// @Produce      json
+// @Param        specialty  query     string  false  "Filter by specialty"
+// @Success      200        {object}  map[string]interface{}
+// @Router       /session-templates [get]
+// @Security     Bearer
+func ListTemplatesHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+
+		query := services.VisibleSessionTemplates(database.GetDB(), currentUser.ID)
+		if specialty := c.Query("specialty"); specialty != "" {
+			query = query.Where("specialty = ?", specialty)
+		}
+
+		var templates []domains.SessionTemplate
+		if err := query.Order("specialty ASC, name ASC").Find(&templates).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch templates"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"data": templates})
+	}
+}
+
+// @Summary      Get session template
+// @Description  Get a session template with its sections, default plan and extra fields
+// @Tags         Templates
+// @Produce      json
+// @Param        id   path      string  true  "Template ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]string
+// @Router       /session-templates/{id} [get]
+// @Security     Bearer
+func GetTemplateHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+
+		var template domains.SessionTemplate
+		if err := services.VisibleSessionTemplates(database.GetDB(), currentUser.ID).First(&template, "id = ?", c.Param("id")).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Template not found"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"data": template})
+	}
+}
+
+// @Summary      Create session template
+// @Description  Create a personal session template, or an organization template when organization_id is sent
+// @Tags         Templates
+// @Accept       json
+// @Produce      json
+// @Param        input  body      domains.SessionTemplateInput  true  "Template Data"
+// @Success      201    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]string
+// @Failure      403    {object}  map[string]string
+// @Router       /session-templates [post]
+// @Security     Bearer
+func CreateTemplateHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+
+		var input domains.SessionTemplateInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		template := domains.SessionTemplate{OwnerID: currentUser.ID}
+		if !applyTemplateInput(c, currentUser, input, &template) {
+			return
+		}
+
+		if err := database.GetDB().Create(&template).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save template"})
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "Template created successfully",
+			"data":    template,
+		})
+	}
+}
+
+// @Summary      Update session template
+// @Description  Replace a session template (owner or organization manager). Existing sessions keep their extra fields.
+// @Tags         Templates
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string                        true  "Template ID"
+// @Param        input  body      domains.SessionTemplateInput  true  "Template Data"
+// @Success      200    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]string
+// @Failure      403    {object}  map[string]string
+// @Failure      404    {object}  map[string]string
+// @Router       /session-templates/{id} [put]
+// @Security     Bearer
+func UpdateTemplateHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+
+		template, ok := loadEditableTemplate(c, currentUser)
+		if !ok {
+			return
+		}
+
+		var input domains.SessionTemplateInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if !applyTemplateInput(c, currentUser, input, &template) {
+			return
+		}
+
+		if err := database.GetDB().Save(&template).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update template"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Template updated successfully",
+			"data":    template,
+		})
+	}
+}
+
+// @Summary      Delete session template
+// @Description  Delete a session template (owner or organization manager). Sessions recorded with it are kept.
+// @Tags         Templates
+// @Produce      json
+// @Param        id   path      string  true  "Template ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Router       /session-templates/{id} [delete]
+// @Security     Bearer
+func DeleteTemplateHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(domains.User)
+
+		template, ok := loadEditableTemplate(c, currentUser)
+		if !ok {
+			return
+		}
+
+		if err := database.GetDB().Delete(&template).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete template"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Template deleted successfully"})
+	}
+}
@@ -0,0 +1,245 @@
+// Package pagination implementa la paginación por cursor común a todos los listados.
+//
+// Los listados se ordenan por un campo permitido (whitelist) y por id como desempate,
+// y cada página se pide con el cursor opaco que devolvió la anterior (keyset pagination),
+// por lo que no hace falta OFFSET ni un COUNT aparte.
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+type Order string
+
+const (
+	Asc  Order = "asc"
+	Desc Order = "desc"
+)
+
+// SortField es un campo por el que se permite ordenar.
+type SortField struct {
+	Column string // Columna SQL
+	Field  string // Campo del struct del que se toma el valor del cursor
+	IsTime bool
+
+	// GroupColumn agrupa antes por esta columna, siempre descendente, y ordena por
+	// Column dentro de cada grupo (p. ej. tickets abiertos primero).
+	GroupColumn string
+	GroupField  string
+}
+
+// Options describe los ordenamientos permitidos de un listado.
+type Options struct {
+	Sorts        map[string]SortField // nombre público -> campo
+	DefaultSort  string
+	DefaultOrder Order
+}
+
+// Cursor es la posición del último elemento entregado.
+type Cursor struct {
+	Sort  string `json:"s"`
+	Order Order  `json:"o"`
+	Value string `json:"v"`
+	ID    string `json:"id"`
+	Group string `json:"g,omitempty"`
+}
+
+func (c Cursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func DecodeCursor(encoded string) (Cursor, error) {
+	var c Cursor
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return c, errors.New("invalid cursor")
+	}
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return c, errors.New("invalid cursor")
+	}
+	return c, nil
+}
+
+// Params son los parámetros de paginación ya validados de una petición.
+type Params struct {
+	Limit  int
+	Sort   string
+	Order  Order
+	Cursor *Cursor
+	field  SortField
+	after  interface{} // Valor del cursor ya convertido al tipo de la columna
+}
+
+// Meta es el envoltorio "meta" que devuelven todos los listados.
+type Meta struct {
+	Limit      int    `json:"limit"`
+	Sort       string `json:"sort"`
+	Order      Order  `json:"order"`
+	HasMore    bool   `json:"has_more"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// Parse lee limit, sort, order y cursor de la query.
+func Parse(c *gin.Context, opts Options) (Params, error) {
+	p := Params{Limit: DefaultLimit, Sort: opts.DefaultSort, Order: opts.DefaultOrder}
+	if p.Order == "" {
+		p.Order = Desc
+	}
+
+	if limit := c.Query("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 1 {
+			return p, errors.New("limit must be a positive number")
+		}
+		p.Limit = n
+		if p.Limit > MaxLimit {
+			p.Limit = MaxLimit
+		}
+	}
+
+	if sortName := c.Query("sort"); sortName != "" {
+		p.Sort = sortName
+	}
+	field, ok := opts.Sorts[p.Sort]
+	if !ok {
+		allowed := make([]string, 0, len(opts.Sorts))
+		for name := range opts.Sorts {
+			allowed = append(allowed, name)
+		}
+		sort.Strings(allowed)
+		return p, fmt.Errorf("invalid sort field. Options: %s", strings.Join(allowed, ", "))
+	}
+	p.field = field
+
+	if order := c.Query("order"); order != "" {
+		p.Order = Order(strings.ToLower(order))
+		if p.Order != Asc && p.Order != Desc {
+			return p, errors.New("order must be asc or desc")
+		}
+	}
+
+	if encoded := c.Query("cursor"); encoded != "" {
+		cursor, err := DecodeCursor(encoded)
+		if err != nil {
+			return p, err
+		}
+		if cursor.Sort != p.Sort || cursor.Order != p.Order {
+			return p, errors.New("cursor does not match the requested sort and order")
+		}
+		if _, err := uuid.Parse(cursor.ID); err != nil {
+			return p, errors.New("invalid cursor")
+		}
+		p.after = cursor.Value
+		if field.IsTime {
+			t, err := time.Parse(time.RFC3339Nano, cursor.Value)
+			if err != nil {
+				return p, errors.New("invalid cursor")
+			}
+			p.after = t
+		}
+		p.Cursor = &cursor
+	}
+
+	return p, nil
+}
+
+// Apply agrega a la consulta el filtro del cursor, el orden y el límite (+1 para detectar más páginas).
+// idColumn permite calificar el id cuando la consulta tiene joins (por defecto "id").
+func (p Params) Apply(query *gorm.DB, idColumn ...string) *gorm.DB {
+	id := "id"
+	if len(idColumn) > 0 {
+		id = idColumn[0]
+	}
+
+	if p.Cursor != nil {
+		op := "<"
+		if p.Order == Asc {
+			op = ">"
+		}
+		keyset := fmt.Sprintf("(%s, %s) %s (?, ?)", p.field.Column, id, op)
+		if group := p.field.GroupColumn; group != "" {
+			query = query.Where(fmt.Sprintf("(%s < ? OR (%s = ? AND %s))", group, group, keyset),
+				p.Cursor.Group, p.Cursor.Group, p.after, p.Cursor.ID)
+		} else {
+			query = query.Where(keyset, p.after, p.Cursor.ID)
+		}
+	}
+
+	direction := "DESC"
+	if p.Order == Asc {
+		direction = "ASC"
+	}
+	order := fmt.Sprintf("%s %s, %s %s", p.field.Column, direction, id, direction)
+	if p.field.GroupColumn != "" {
+		order = p.field.GroupColumn + " DESC, " + order
+	}
+	return query.Order(order).Limit(p.Limit + 1)
+}
+
+// Trim recorta el elemento extra pedido por Apply y arma el Meta.
+// items debe ser un puntero a slice de structs con campo ID.
+func (p Params) Trim(items interface{}) Meta {
+	meta := Meta{Limit: p.Limit, Sort: p.Sort, Order: p.Order}
+
+	slice := reflect.ValueOf(items).Elem()
+	if slice.Len() <= p.Limit {
+		return meta
+	}
+
+	slice.Set(slice.Slice(0, p.Limit))
+	meta.HasMore = true
+
+	last := reflect.Indirect(slice.Index(p.Limit - 1))
+	cursor := Cursor{
+		Sort:  p.Sort,
+		Order: p.Order,
+		Value: formatValue(last.FieldByName(p.field.Field).Interface()),
+		ID:    fmt.Sprint(last.FieldByName("ID").Interface()),
+	}
+	if p.field.GroupField != "" {
+		cursor.Group = formatValue(last.FieldByName(p.field.GroupField).Interface())
+	}
+	meta.NextCursor = cursor.Encode()
+
+	return meta
+}
+
+// Find ejecuta la consulta paginada sobre dest (puntero a slice) y devuelve el Meta.
+func (p Params) Find(query *gorm.DB, dest interface{}) (Meta, error) {
+	if err := p.Apply(query).Find(dest).Error; err != nil {
+		return Meta{}, err
+	}
+	return p.Trim(dest), nil
+}
+
+func formatValue(v interface{}) string {
+	switch value := v.(type) {
+	case time.Time:
+		return value.UTC().Format(time.RFC3339Nano)
+	case *time.Time:
+		if value == nil {
+			return ""
+		}
+		return value.UTC().Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(value)
+	}
+}
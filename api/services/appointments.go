@@ -0,0 +1,110 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"bitacora-medica-backend/api/domains"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TimeSlot es el intervalo [Start, End) de una ocurrencia.
+type TimeSlot struct {
+	Start time.Time
+	End   time.Time
+}
+
+// ExpandRecurrence genera las ocurrencias de una cita. Sin regla, devuelve solo la primera.
+// Devuelve también la regla normalizada (ej: "WEEKLY;INTERVAL=1;COUNT=10") para guardarla.
+func ExpandRecurrence(first TimeSlot, rule *domains.RecurrenceInput) ([]TimeSlot, string, error) {
+	if rule == nil {
+		return []TimeSlot{first}, "", nil
+	}
+
+	interval := rule.Interval
+	if interval == 0 {
+		interval = 1
+	}
+
+	var until time.Time
+	if rule.Until != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", rule.Until, first.Start.Location())
+		if err != nil {
+			return nil, "", errors.New("invalid recurrence until date (YYYY-MM-DD)")
+		}
+		until = parsed.AddDate(0, 0, 1) // inclusivo
+	}
+	if rule.Count == 0 && until.IsZero() {
+		return nil, "", errors.New("recurrence requires count or until")
+	}
+
+	var slots []TimeSlot
+	for i := 0; i < domains.MaxRecurrenceOccurrences; i++ {
+		if rule.Count > 0 && i >= rule.Count {
+			break
+		}
+		// AddDate mantiene la hora local aunque cambie el horario de verano
+		slot := TimeSlot{
+			Start: first.Start.AddDate(0, 0, 7*interval*i),
+			End:   first.End.AddDate(0, 0, 7*interval*i),
+		}
+		if !until.IsZero() && !slot.Start.Before(until) {
+			break
+		}
+		slots = append(slots, slot)
+	}
+	if len(slots) == 0 {
+		return nil, "", errors.New("recurrence generates no appointments: until is before the first appointment")
+	}
+
+	normalized := fmt.Sprintf("%s;INTERVAL=%d", rule.Frequency, interval)
+	if rule.Count > 0 {
+		normalized += fmt.Sprintf(";COUNT=%d", rule.Count)
+	}
+	if rule.Until != "" {
+		normalized += ";UNTIL=" + rule.Until
+	}
+
+	return slots, normalized, nil
+}
+
+// LockProfessionalSchedule bloquea (SELECT ... FOR UPDATE) la fila del profesional
+// hasta el fin de la transacción, para que dos agendamientos simultáneos no pasen
+// ambos la verificación de conflictos. Se bloquea el usuario y no sus citas porque
+// un horario libre no tiene filas que bloquear.
+func LockProfessionalSchedule(tx *gorm.DB, professionalID uuid.UUID) error {
+	var professional domains.User
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		First(&professional, "id = ?", professionalID).Error
+}
+
+// FindAppointmentConflicts busca citas abiertas del profesional que se solapan con alguno de los intervalos.
+// excludeIDs permite ignorar las citas que se están reprogramando.
+func FindAppointmentConflicts(db *gorm.DB, professionalID uuid.UUID, slots []TimeSlot, excludeIDs ...uuid.UUID) ([]domains.Appointment, error) {
+	if len(slots) == 0 {
+		return nil, nil
+	}
+
+	base := db.Session(&gorm.Session{NewDB: true})
+	overlap := base.Where("starts_at < ? AND ends_at > ?", slots[0].End, slots[0].Start)
+	for _, slot := range slots[1:] {
+		overlap = overlap.Or("starts_at < ? AND ends_at > ?", slot.End, slot.Start)
+	}
+
+	query := base.Model(&domains.Appointment{}).
+		Where("professional_id = ? AND status IN ?", professionalID,
+			[]domains.AppointmentStatus{domains.AppointmentScheduled, domains.AppointmentConfirmed}).
+		Where(overlap)
+	if len(excludeIDs) > 0 {
+		query = query.Where("id NOT IN ?", excludeIDs)
+	}
+
+	var conflicts []domains.Appointment
+	err := query.Order("starts_at ASC").Find(&conflicts).Error
+	return conflicts, err
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"sort"
+
+	"bitacora-medica-backend/api/domains"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type attendanceCount struct {
+	GroupID uuid.UUID
+	Status  domains.AttendanceStatus
+	Count   int64
+}
+
+func addAttendance(stats *domains.AttendanceStats, status domains.AttendanceStatus, count int64) {
+	stats.Total += count
+	switch status {
+	case domains.AttendanceAttended:
+		stats.Attended += count
+	case domains.AttendanceCancelledByFamily:
+		stats.CancelledByFamily += count
+	case domains.AttendanceCancelledByProfessional:
+		stats.CancelledByProfessional += count
+	case domains.AttendanceNoShow:
+		stats.NoShow += count
+	}
+	if stats.Total > 0 {
+		stats.AttendanceRate = float64(stats.Attended) / float64(stats.Total)
+		stats.NoShowRate = float64(stats.NoShow) / float64(stats.Total)
+	}
+}
+
+// SummarizeAttendance cuenta los registros de asistencia de la consulta
+// (un db.Model(&domains.AttendanceRecord{}) ya filtrado).
+func SummarizeAttendance(query *gorm.DB) domains.AttendanceStats {
+	var rows []attendanceCount
+	query.Select("status, count(*) as count").Group("status").Scan(&rows)
+
+	var stats domains.AttendanceStats
+	for _, r := range rows {
+		addAttendance(&stats, r.Status, r.Count)
+	}
+	return stats
+}
+
+// attendanceBy agrupa los registros de la consulta por la columna indicada.
+func attendanceBy(query *gorm.DB, column string) map[uuid.UUID]*domains.AttendanceStats {
+	var rows []attendanceCount
+	query.Select(column + " as group_id, status, count(*) as count").
+		Group(column + ", status").
+		Scan(&rows)
+
+	grouped := make(map[uuid.UUID]*domains.AttendanceStats)
+	for _, r := range rows {
+		if grouped[r.GroupID] == nil {
+			grouped[r.GroupID] = &domains.AttendanceStats{}
+		}
+		addAttendance(grouped[r.GroupID], r.Status, r.Count)
+	}
+	return grouped
+}
+
+// AttendanceByPatient devuelve la asistencia por paciente, de menor a mayor tasa
+// de asistencia (los casos que requieren seguimiento primero).
+func AttendanceByPatient(db *gorm.DB, query *gorm.DB) []domains.PatientAttendanceStats {
+	grouped := attendanceBy(query, "patient_id")
+	if len(grouped) == 0 {
+		return []domains.PatientAttendanceStats{}
+	}
+
+	ids := make([]uuid.UUID, 0, len(grouped))
+	for id := range grouped {
+		ids = append(ids, id)
+	}
+	var patients []domains.Patient
+	db.Select("id, personal_info").Where("id IN ?", ids).Find(&patients)
+	names := make(map[uuid.UUID]string, len(patients))
+	for _, p := range patients {
+		names[p.ID] = PatientFullName(p.PersonalInfo)
+	}
+
+	result := make([]domains.PatientAttendanceStats, 0, len(grouped))
+	for id, stats := range grouped {
+		result = append(result, domains.PatientAttendanceStats{
+			PatientID:   id,
+			PatientName: names[id],
+			Stats:       *stats,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Stats.AttendanceRate != result[j].Stats.AttendanceRate {
+			return result[i].Stats.AttendanceRate < result[j].Stats.AttendanceRate
+		}
+		return result[i].Stats.Total > result[j].Stats.Total
+	})
+	return result
+}
+
+// AttendanceByProfessional devuelve la asistencia por profesional.
+func AttendanceByProfessional(db *gorm.DB, query *gorm.DB) []domains.ProfessionalAttendanceStats {
+	grouped := attendanceBy(query, "professional_id")
+	if len(grouped) == 0 {
+		return []domains.ProfessionalAttendanceStats{}
+	}
+
+	ids := make([]uuid.UUID, 0, len(grouped))
+	for id := range grouped {
+		ids = append(ids, id)
+	}
+	var users []domains.User
+	db.Select("id, email").Where("id IN ?", ids).Find(&users)
+	names := make(map[uuid.UUID]string, len(users))
+	for _, u := range users {
+		names[u.ID] = u.Email
+	}
+
+	result := make([]domains.ProfessionalAttendanceStats, 0, len(grouped))
+	for id, stats := range grouped {
+		result = append(result, domains.ProfessionalAttendanceStats{
+			ProfessionalID:   id,
+			ProfessionalName: names[id],
+			Stats:            *stats,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ProfessionalName < result[j].ProfessionalName })
+	return result
+}
+
+// RecordAppointmentAttendance deja un único registro de asistencia por cita,
+// reemplazando el anterior. Con status vacío solo borra (cita reabierta).
+func RecordAppointmentAttendance(tx *gorm.DB, appointment domains.Appointment, status domains.AttendanceStatus, reason string, recordedByID uuid.UUID, sessionID *uuid.UUID) error {
+	if err := tx.Where("appointment_id = ?", appointment.ID).Delete(&domains.AttendanceRecord{}).Error; err != nil {
+		return err
+	}
+	if status == "" {
+		return nil
+	}
+
+	record := domains.AttendanceRecord{
+		PatientID:      appointment.PatientID,
+		ProfessionalID: appointment.ProfessionalID,
+		AppointmentID:  &appointment.ID,
+		SessionID:      sessionID,
+		Status:         status,
+		Reason:         reason,
+		OccurredAt:     appointment.StartsAt,
+		RecordedByID:   recordedByID,
+	}
+	return tx.Create(&record).Error
+}
+
+// RecordSessionAttendance registra como asistida una sesión sin cita previa.
+func RecordSessionAttendance(tx *gorm.DB, session domains.Session) error {
+	record := domains.AttendanceRecord{
+		PatientID:      session.PatientID,
+		ProfessionalID: session.ProfessionalID,
+		SessionID:      &session.ID,
+		Status:         domains.AttendanceAttended,
+		OccurredAt:     session.CreatedAt,
+		RecordedByID:   session.ProfessionalID,
+	}
+	return tx.Create(&record).Error
+}
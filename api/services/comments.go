@@ -0,0 +1,51 @@
+package services
+
+import (
+	"bitacora-medica-backend/api/domains"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CommentThreadScope filtra los comentarios de un paciente o, si sessionID no es
+// nil, de una de sus sesiones.
+func CommentThreadScope(db *gorm.DB, patientID uuid.UUID, sessionID *uuid.UUID) *gorm.DB {
+	query := db.Model(&domains.Comment{}).Where("patient_id = ?", patientID)
+	if sessionID != nil {
+		return query.Where("session_id = ?", *sessionID)
+	}
+	return query.Where("session_id IS NULL")
+}
+
+// CommentParticipants devuelve a quién avisar de un comentario nuevo: los autores
+// del hilo (o de los comentarios del paciente/sesión si es un comentario raíz) y
+// el autor de la sesión, sin incluir a quien comenta ni a quien ya no está en el
+// equipo tratante.
+func CommentParticipants(db *gorm.DB, comment domains.Comment) []uuid.UUID {
+	var authorIDs []uuid.UUID
+	query := CommentThreadScope(db, comment.PatientID, comment.SessionID)
+	if comment.ParentID != nil {
+		query = query.Where("id = ? OR parent_id = ?", *comment.ParentID, *comment.ParentID)
+	}
+	query.Distinct("author_id").Pluck("author_id", &authorIDs)
+
+	if comment.SessionID != nil {
+		var session domains.Session
+		if err := db.Select("professional_id").First(&session, "id = ?", *comment.SessionID).Error; err == nil {
+			authorIDs = append(authorIDs, session.ProfessionalID)
+		}
+	}
+
+	seen := map[uuid.UUID]bool{comment.AuthorID: true}
+	var participants []uuid.UUID
+	for _, id := range authorIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if IsCareTeamMember(db, id, comment.PatientID) {
+			participants = append(participants, id)
+		}
+	}
+	return participants
+}
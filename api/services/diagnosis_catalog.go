@@ -0,0 +1,156 @@
+package services
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"bitacora-medica-backend/api/config"
+	"bitacora-medica-backend/api/domains"
+)
+
+//go:embed data/cie10.csv
+var bundledCIE10 []byte
+
+type DiagnosisCatalog struct {
+	entries []domains.DiagnosisCatalogEntry
+	byCode  map[string]domains.DiagnosisCatalogEntry
+}
+
+var (
+	catalogOnce sync.Once
+	catalog     *DiagnosisCatalog
+	catalogErr  error
+)
+
+// GetDiagnosisCatalog carga el catálogo CIE-10 una sola vez por proceso.
+// Si CIE10_CATALOG_PATH está definido se usa ese archivo; el incluido es solo una
+// muestra y únicamente se acepta en desarrollo.
+func GetDiagnosisCatalog(cfg *config.Config) (*DiagnosisCatalog, error) {
+	catalogOnce.Do(func() {
+		data := bundledCIE10
+		if cfg.CIE10CatalogPath == "" {
+			if !cfg.IsDevelopment() {
+				catalogErr = errors.New("CIE10_CATALOG_PATH is required outside development: the bundled CIE-10 catalog is only a sample")
+				return
+			}
+			slog.Warn("CIE10_CATALOG_PATH not set, using the bundled sample catalog")
+		} else {
+			fileData, err := os.ReadFile(cfg.CIE10CatalogPath)
+			if err != nil {
+				catalogErr = fmt.Errorf("reading CIE-10 catalog: %w", err)
+				return
+			}
+			data = fileData
+		}
+
+		catalog, catalogErr = parseDiagnosisCatalog(data)
+		if catalogErr == nil {
+			slog.Info("CIE-10 catalog loaded", "entries", len(catalog.entries))
+		}
+	})
+	return catalog, catalogErr
+}
+
+func parseDiagnosisCatalog(data []byte) (*DiagnosisCatalog, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+
+	// Cabecera: code,description,group_code,group_name
+	if _, err := reader.Read(); err != nil {
+		return nil, fmt.Errorf("reading CIE-10 header: %w", err)
+	}
+
+	cat := &DiagnosisCatalog{byCode: make(map[string]domains.DiagnosisCatalogEntry)}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading CIE-10 row: %w", err)
+		}
+		if len(record) < 4 {
+			continue
+		}
+
+		entry := domains.DiagnosisCatalogEntry{
+			Code:        strings.ToUpper(strings.TrimSpace(record[0])),
+			Description: strings.TrimSpace(record[1]),
+			GroupCode:   strings.TrimSpace(record[2]),
+			GroupName:   strings.TrimSpace(record[3]),
+		}
+		cat.entries = append(cat.entries, entry)
+		cat.byCode[normalizeCode(entry.Code)] = entry
+	}
+
+	sort.Slice(cat.entries, func(i, j int) bool { return cat.entries[i].Code < cat.entries[j].Code })
+	return cat, nil
+}
+
+// Lookup busca un código exacto; acepta el código con o sin punto (F84.0 / F840).
+func (c *DiagnosisCatalog) Lookup(code string) (domains.DiagnosisCatalogEntry, bool) {
+	entry, ok := c.byCode[normalizeCode(code)]
+	return entry, ok
+}
+
+// Search devuelve primero los códigos que comienzan con el término y luego las
+// descripciones que contienen todas sus palabras, sin distinguir tildes.
+func (c *DiagnosisCatalog) Search(term string, limit int) []domains.DiagnosisCatalogEntry {
+	codeTerm := normalizeCode(term)
+	words := strings.Fields(foldText(term))
+
+	results := make([]domains.DiagnosisCatalogEntry, 0, limit)
+	seen := make(map[string]bool)
+
+	for _, e := range c.entries {
+		if len(results) >= limit {
+			return results
+		}
+		if codeTerm != "" && strings.HasPrefix(normalizeCode(e.Code), codeTerm) {
+			results = append(results, e)
+			seen[e.Code] = true
+		}
+	}
+
+	for _, e := range c.entries {
+		if len(results) >= limit {
+			break
+		}
+		if seen[e.Code] || len(words) == 0 {
+			continue
+		}
+		description := foldText(e.Description)
+		matches := true
+		for _, w := range words {
+			if !strings.Contains(description, w) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			results = append(results, e)
+		}
+	}
+
+	return results
+}
+
+func normalizeCode(code string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(code), ".", ""))
+}
+
+var accentReplacer = strings.NewReplacer(
+	"á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ü", "u", "ñ", "n",
+)
+
+func foldText(s string) string {
+	return accentReplacer.Replace(strings.ToLower(s))
+}
@@ -0,0 +1,172 @@
+package services
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"bitacora-medica-backend/api/domains"
+
+	"gorm.io/datatypes"
+)
+
+// ICSEvent es un VEVENT del calendario.
+type ICSEvent struct {
+	UID         string
+	Start       time.Time
+	End         time.Time
+	Updated     time.Time
+	Summary     string
+	Description string
+	Location    string
+	Status      string // CONFIRMED, TENTATIVE o CANCELLED
+}
+
+const icsTimeFormat = "20060102T150405Z"
+
+// BuildICS arma un VCALENDAR según RFC 5545: líneas CRLF, texto escapado y
+// plegado de líneas a 75 octetos.
+func BuildICS(calendarName string, events []ICSEvent) string {
+	var sb strings.Builder
+	write := func(line string) {
+		sb.WriteString(foldICSLine(line))
+		sb.WriteString("\r\n")
+	}
+
+	write("BEGIN:VCALENDAR")
+	write("VERSION:2.0")
+	write("PRODID:-//MedLog//Bitacora Medica//ES")
+	write("CALSCALE:GREGORIAN")
+	write("METHOD:PUBLISH")
+	write("X-WR-CALNAME:" + escapeICSText(calendarName))
+
+	now := time.Now().UTC().Format(icsTimeFormat)
+	for _, e := range events {
+		write("BEGIN:VEVENT")
+		write("UID:" + e.UID)
+		write("DTSTAMP:" + now)
+		write("DTSTART:" + e.Start.UTC().Format(icsTimeFormat))
+		write("DTEND:" + e.End.UTC().Format(icsTimeFormat))
+		if !e.Updated.IsZero() {
+			write("LAST-MODIFIED:" + e.Updated.UTC().Format(icsTimeFormat))
+		}
+		write("SUMMARY:" + escapeICSText(e.Summary))
+		if e.Description != "" {
+			write("DESCRIPTION:" + escapeICSText(e.Description))
+		}
+		if e.Location != "" {
+			write("LOCATION:" + escapeICSText(e.Location))
+		}
+		if e.Status != "" {
+			write("STATUS:" + e.Status)
+		}
+		write("END:VEVENT")
+	}
+
+	write("END:VCALENDAR")
+	return sb.String()
+}
+
+// escapeICSText escapa un valor TEXT (RFC 5545 §3.3.11).
+func escapeICSText(s string) string {
+	s = strings.ReplaceAll(s, "\\", "\\\\")
+	s = strings.ReplaceAll(s, ";", "\\;")
+	s = strings.ReplaceAll(s, ",", "\\,")
+	s = strings.ReplaceAll(s, "\r\n", "\\n")
+	s = strings.ReplaceAll(s, "\n", "\\n")
+	s = strings.ReplaceAll(s, "\r", "\\n")
+	return s
+}
+
+// foldICSLine parte las líneas de más de 75 octetos sin cortar caracteres UTF-8;
+// cada continuación empieza con un espacio (RFC 5545 §3.1).
+func foldICSLine(line string) string {
+	const limit = 75
+	if len(line) <= limit {
+		return line
+	}
+
+	var sb strings.Builder
+	width := 0
+	max := limit
+	for _, r := range line {
+		size := utf8.RuneLen(r)
+		if width+size > max {
+			sb.WriteString("\r\n ")
+			width = 0
+			max = limit - 1 // el espacio inicial cuenta
+		}
+		sb.WriteRune(r)
+		width += size
+	}
+	return sb.String()
+}
+
+// AppointmentICSEvent convierte una cita en evento. Con fullNames=false el paciente
+// aparece solo con sus iniciales para no exponer datos sensibles en calendarios externos.
+func AppointmentICSEvent(a domains.Appointment, fullNames bool) ICSEvent {
+	patient := PatientInitials(a.Patient.PersonalInfo)
+	if fullNames {
+		patient = PatientFullName(a.Patient.PersonalInfo)
+	}
+
+	modality := map[domains.AppointmentModality]string{
+		domains.ModalityInPerson:   "Presencial",
+		domains.ModalityHomeVisit:  "Visita domiciliaria",
+		domains.ModalityTelehealth: "Telemedicina",
+	}[a.Modality]
+
+	status := "CONFIRMED"
+	switch a.Status {
+	case domains.AppointmentScheduled:
+		status = "TENTATIVE"
+	case domains.AppointmentCancelled, domains.AppointmentNoShow:
+		status = "CANCELLED"
+	}
+
+	return ICSEvent{
+		UID:         a.ID.String() + "@medlog",
+		Start:       a.StartsAt,
+		End:         a.EndsAt,
+		Updated:     a.UpdatedAt,
+		Summary:     "Sesión " + patient,
+		Description: modality,
+		Location:    a.Location,
+		Status:      status,
+	}
+}
+
+type patientName struct {
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+}
+
+func parsePatientName(personalInfo datatypes.JSON) patientName {
+	var name patientName
+	_ = json.Unmarshal(personalInfo, &name)
+	return name
+}
+
+// PatientInitials devuelve las iniciales del paciente (ej: "J.P.").
+func PatientInitials(personalInfo datatypes.JSON) string {
+	name := parsePatientName(personalInfo)
+	initials := ""
+	for _, part := range strings.Fields(name.FirstName + " " + name.LastName) {
+		r, _ := utf8.DecodeRuneInString(part)
+		initials += strings.ToUpper(string(r)) + "."
+	}
+	if initials == "" {
+		return "Paciente"
+	}
+	return initials
+}
+
+func PatientFullName(personalInfo datatypes.JSON) string {
+	name := parsePatientName(personalInfo)
+	full := strings.TrimSpace(name.FirstName + " " + name.LastName)
+	if full == "" {
+		return "Paciente"
+	}
+	return full
+}
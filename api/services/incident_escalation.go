@@ -0,0 +1,128 @@
+package services
+
+import (
+	"log/slog"
+	"time"
+
+	"bitacora-medica-backend/api/config"
+	"bitacora-medica-backend/api/database"
+	"bitacora-medica-backend/api/domains"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+const escalationCheckInterval = time.Minute
+
+// StartIncidentEscalation revisa periódicamente los incidentes abiertos sin
+// reconocer y los escala. Cada paso se aplica tras otro intervalo de
+// IncidentEscalationMinutes desde el reporte.
+func StartIncidentEscalation(cfg *config.Config) {
+	if cfg.IncidentEscalationMinutes <= 0 {
+		slog.Info("Incident escalation disabled")
+		return
+	}
+
+	minSeverity := domains.Severity(cfg.IncidentEscalationMinSeverity)
+	if minSeverity.Rank() == 0 {
+		slog.Warn("Invalid INCIDENT_ESCALATION_MIN_SEVERITY, using HIGH", "value", cfg.IncidentEscalationMinSeverity)
+		minSeverity = domains.SeverityHigh
+	}
+	wait := time.Duration(cfg.IncidentEscalationMinutes) * time.Minute
+
+	go func() {
+		ticker := time.NewTicker(escalationCheckInterval)
+		defer ticker.Stop()
+		for now := range ticker.C {
+			EscalateIncidents(cfg, minSeverity, wait, now)
+		}
+	}()
+}
+
+// EscalateIncidents aplica el siguiente paso de escalación a los incidentes que
+// lo tienen vencido. El paso se reserva con una actualización condicional, así
+// varias instancias del servidor no notifican dos veces.
+func EscalateIncidents(cfg *config.Config, minSeverity domains.Severity, wait time.Duration, now time.Time) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Error("PANIC in incident escalation", "recover", r)
+		}
+	}()
+
+	db := database.GetDB()
+
+	var incidents []domains.Incident
+	err := db.Where("status = ? AND severity IN ? AND escalation_level < ? AND created_at <= ?",
+		domains.IncidentOpen, domains.SeveritiesAtLeast(minSeverity), domains.EscalationManagement, now.Add(-wait)).
+		Find(&incidents).Error
+	if err != nil {
+		slog.Error("Failed to fetch incidents to escalate", "error", err)
+		return
+	}
+
+	notifier := NewNotificationService(cfg)
+	for _, incident := range incidents {
+		level := incident.EscalationLevel + 1
+		if incident.CreatedAt.Add(wait * time.Duration(level)).After(now) {
+			continue
+		}
+
+		recipients := escalationRecipients(db, incident, level)
+		recipientIDs := make(pq.StringArray, 0, len(recipients))
+		for _, u := range recipients {
+			recipientIDs = append(recipientIDs, u.ID.String())
+		}
+
+		claimed := false
+		err := db.Transaction(func(tx *gorm.DB) error {
+			result := tx.Model(&domains.Incident{}).
+				Where("id = ? AND status = ? AND escalation_level = ?", incident.ID, domains.IncidentOpen, incident.EscalationLevel).
+				Update("escalation_level", level)
+			if result.Error != nil || result.RowsAffected == 0 {
+				return result.Error
+			}
+			claimed = true
+			return tx.Create(&domains.IncidentEscalation{
+				IncidentID:   incident.ID,
+				Level:        level,
+				RecipientIDs: recipientIDs,
+			}).Error
+		})
+		if err != nil {
+			slog.Error("Failed to escalate incident", "incident_id", incident.ID, "error", err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		slog.Warn("INCIDENT ESCALATED", "incident_id", incident.ID, "level", level, "recipients", len(recipients))
+		notifier.NotifyIncidentEscalation(incident, level, recipients)
+	}
+}
+
+// escalationRecipients devuelve a quién avisar en cada paso: el equipo tratante
+// en el recordatorio; el dueño del paciente, los administradores y los gestores
+// de las organizaciones del dueño en el paso de gestión.
+func escalationRecipients(db *gorm.DB, incident domains.Incident, level int) []domains.User {
+	var patient domains.Patient
+	db.Select("id", "creator_id").First(&patient, "id = ?", incident.PatientID)
+
+	var users []domains.User
+	if level == domains.EscalationReminder {
+		db.Where("id IN (?)", db.Table("collaborations").
+			Select("professional_id").
+			Where("patient_id = ? AND status = ?", incident.PatientID, domains.CollabAccepted)).
+			Or("id = ?", patient.CreatorID).
+			Find(&users)
+		return users
+	}
+
+	db.Where("id = ?", patient.CreatorID).
+		Or("role = ? AND status = ?", domains.RoleAdmin, domains.StatusActive).
+		Or("id IN (?)", db.Model(&domains.OrganizationMember{}).
+			Select("user_id").
+			Where("role = ? AND organization_id IN (?)", domains.OrgManager, UserOrganizationIDs(db, patient.CreatorID))).
+		Find(&users)
+	return users
+}
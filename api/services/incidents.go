@@ -0,0 +1,77 @@
+package services
+
+import (
+	"bitacora-medica-backend/api/domains"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// VisibleIncidents filtra los incidentes de los pacientes a los que el usuario tiene acceso.
+func VisibleIncidents(db *gorm.DB, user domains.User) *gorm.DB {
+	query := db.Model(&domains.Incident{})
+	if user.Role == domains.RoleAdmin {
+		return query
+	}
+	return query.Where("patient_id IN (?)", AccessiblePatientIDs(db, user.ID))
+}
+
+// OpenSessionIncident crea el incidente de una sesión que lo reporta, si aún no
+// existe. severity y category vacíos usan MODERATE y OTHER.
+func OpenSessionIncident(tx *gorm.DB, session domains.Session, severity, category string) error {
+	var existing int64
+	tx.Model(&domains.Incident{}).Where("session_id = ?", session.ID).Count(&existing)
+	if existing > 0 {
+		return nil
+	}
+
+	incident := domains.Incident{
+		PatientID:    session.PatientID,
+		SessionID:    &session.ID,
+		ReportedByID: session.ProfessionalID,
+		Severity:     domains.SeverityModerate,
+		Category:     domains.IncidentOther,
+		Details:      session.IncidentDetails,
+		Photo:        session.IncidentPhoto,
+		Status:       domains.IncidentOpen,
+	}
+	if severity != "" {
+		incident.Severity = domains.Severity(severity)
+	}
+	if category != "" {
+		incident.Category = domains.IncidentCategory(category)
+	}
+	return tx.Create(&incident).Error
+}
+
+// CountIncidentsByStatus agrupa por estado los incidentes de la consulta recibida.
+// Todos los estados aparecen en el resultado, aunque su conteo sea cero.
+func CountIncidentsByStatus(query *gorm.DB) map[string]int64 {
+	counts := make(map[string]int64, len(domains.IncidentStatuses))
+	for _, status := range domains.IncidentStatuses {
+		counts[string(status)] = 0
+	}
+
+	type statusCount struct {
+		Status string
+		Count  int64
+	}
+	var results []statusCount
+	query.Select("status, count(*) as count").Group("status").Scan(&results)
+
+	for _, r := range results {
+		counts[r.Status] = r.Count
+	}
+	return counts
+}
+
+// IsCareTeamMember indica si el usuario es creador o colaborador aceptado del paciente.
+// A diferencia de CanAccessPatient, el rol de administrador no cuenta.
+func IsCareTeamMember(db *gorm.DB, userID, patientID uuid.UUID) bool {
+	var count int64
+	db.Session(&gorm.Session{NewDB: true}).
+		Model(&domains.Patient{}).
+		Where("id = ? AND id IN (?)", patientID, AccessiblePatientIDs(db, userID)).
+		Count(&count)
+	return count > 0
+}
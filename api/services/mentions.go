@@ -0,0 +1,181 @@
+package services
+
+import (
+	"encoding/json"
+	"strings"
+
+	"bitacora-medica-backend/api/domains"
+	"bitacora-medica-backend/api/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const maxMentionExcerpt = 280
+
+// CareTeamUsers devuelve el creador del paciente y sus colaboradores aceptados.
+func CareTeamUsers(db *gorm.DB, patientID uuid.UUID) []domains.User {
+	var users []domains.User
+	base := db.Session(&gorm.Session{NewDB: true})
+	base.Where("id IN (?)", base.Model(&domains.Patient{}).Select("creator_id").Where("id = ?", patientID)).
+		Or("id IN (?)", base.Table("collaborations").
+			Select("professional_id").
+			Where("patient_id = ? AND status = ?", patientID, domains.CollabAccepted)).
+		Find(&users)
+	return users
+}
+
+// mentionHandles devuelve cómo se puede mencionar a un usuario: la parte local de
+// su correo y, a partir del perfil, su nombre de pila y su nombre completo sin espacios.
+func mentionHandles(user domains.User) (emailHandle string, nameHandles []string) {
+	emailHandle = utils.NormalizeHandle(strings.SplitN(user.Email, "@", 2)[0])
+
+	var profile struct {
+		FullName  string `json:"full_name"`
+		FirstName string `json:"first_name"`
+		LastName  string `json:"last_name"`
+	}
+	_ = json.Unmarshal(user.ProfileData, &profile)
+
+	fullName := profile.FullName
+	if fullName == "" {
+		fullName = strings.TrimSpace(profile.FirstName + " " + profile.LastName)
+	}
+	if fields := strings.Fields(fullName); len(fields) > 0 {
+		nameHandles = append(nameHandles,
+			utils.NormalizeHandle(fields[0]),
+			utils.NormalizeHandle(strings.Join(fields, "")),
+			utils.NormalizeHandle(strings.Join(fields, ".")))
+	}
+	return emailHandle, nameHandles
+}
+
+// ResolveMentions traduce los identificadores mencionados a miembros del equipo.
+// La parte local del correo tiene prioridad sobre el nombre, y en ambos casos
+// solo se acepta si corresponde a una única persona del equipo.
+func ResolveMentions(team []domains.User, handles []string) []domains.User {
+	byEmail := make(map[string][]domains.User)
+	byName := make(map[string][]domains.User)
+	for _, user := range team {
+		emailHandle, nameHandles := mentionHandles(user)
+		byEmail[emailHandle] = append(byEmail[emailHandle], user)
+		own := make(map[string]bool) // Con un solo nombre, sus variantes coinciden
+		for _, h := range nameHandles {
+			if !own[h] {
+				own[h] = true
+				byName[h] = append(byName[h], user)
+			}
+		}
+	}
+
+	var resolved []domains.User
+	seen := make(map[uuid.UUID]bool)
+	for _, handle := range handles {
+		var user domains.User
+		ok := false
+		if candidates := byEmail[handle]; len(candidates) > 0 {
+			// Correo ambiguo: no se recurre al nombre, que también podría serlo
+			user, ok = candidates[0], len(candidates) == 1
+		} else if candidates := byName[handle]; len(candidates) == 1 {
+			user, ok = candidates[0], true
+		}
+		if ok && !seen[user.ID] {
+			seen[user.ID] = true
+			resolved = append(resolved, user)
+		}
+	}
+	return resolved
+}
+
+// MentionSource identifica dónde se escribió el texto con menciones.
+type MentionSource struct {
+	AuthorID  uuid.UUID
+	PatientID uuid.UUID
+	SessionID *uuid.UUID
+	CommentID *uuid.UUID
+}
+
+// RecordMentions crea las menciones de los textos recibidos (campo -> texto) y
+// devuelve solo las nuevas: un usuario ya mencionado en el mismo campo no se
+// vuelve a registrar al editar. El autor no se menciona a sí mismo.
+func RecordMentions(tx *gorm.DB, source MentionSource, fields map[string]string) ([]domains.Mention, error) {
+	var team []domains.User
+	var created []domains.Mention
+
+	for field, text := range fields {
+		handles := utils.ParseMentions(text)
+		if len(handles) == 0 {
+			continue
+		}
+		if team == nil {
+			team = CareTeamUsers(tx, source.PatientID)
+		}
+
+		for _, user := range ResolveMentions(team, handles) {
+			if user.ID == source.AuthorID {
+				continue
+			}
+
+			existing := tx.Model(&domains.Mention{}).Where("mentioned_user_id = ? AND field = ?", user.ID, field)
+			if source.CommentID != nil {
+				existing = existing.Where("comment_id = ?", *source.CommentID)
+			} else {
+				existing = existing.Where("session_id = ?", source.SessionID)
+			}
+			var count int64
+			existing.Count(&count)
+			if count > 0 {
+				continue
+			}
+
+			mention := domains.Mention{
+				MentionedUserID: user.ID,
+				AuthorID:        source.AuthorID,
+				PatientID:       source.PatientID,
+				SessionID:       source.SessionID,
+				CommentID:       source.CommentID,
+				Field:           field,
+				Excerpt:         mentionExcerpt(text),
+			}
+			if err := tx.Create(&mention).Error; err != nil {
+				return nil, err
+			}
+			created = append(created, mention)
+		}
+	}
+	return created, nil
+}
+
+// RecordSessionMentions registra las menciones de los campos de texto de la sesión.
+func RecordSessionMentions(tx *gorm.DB, session domains.Session) ([]domains.Mention, error) {
+	return RecordMentions(tx, MentionSource{
+		AuthorID:  session.ProfessionalID,
+		PatientID: session.PatientID,
+		SessionID: &session.ID,
+	}, map[string]string{
+		"intervention_plan":   session.InterventionPlan,
+		"description":         session.Description,
+		"achievements":        session.Achievements,
+		"patient_performance": session.PatientPerformance,
+		"incident_details":    session.IncidentDetails,
+		"next_session_notes":  session.NextSessionNotes,
+	})
+}
+
+// RecordCommentMentions registra las menciones del texto de un comentario.
+func RecordCommentMentions(tx *gorm.DB, comment domains.Comment) ([]domains.Mention, error) {
+	return RecordMentions(tx, MentionSource{
+		AuthorID:  comment.AuthorID,
+		PatientID: comment.PatientID,
+		SessionID: comment.SessionID,
+		CommentID: &comment.ID,
+	}, map[string]string{domains.MentionFieldComment: comment.Body})
+}
+
+func mentionExcerpt(text string) string {
+	runes := []rune(strings.TrimSpace(text))
+	if len(runes) <= maxMentionExcerpt {
+		return string(runes)
+	}
+	return string(runes[:maxMentionExcerpt]) + "…"
+}
@@ -3,8 +3,10 @@ package services
 import (
 	"encoding/json"
 	"fmt"
+	"html"
 	"log/slog"
 	"net/smtp"
+	"strings"
 
 	"bitacora-medica-backend/api/config"
 	"bitacora-medica-backend/api/database"
@@ -21,7 +23,8 @@ func NewNotificationService(cfg *config.Config) *NotificationService {
 	return &NotificationService{cfg: cfg}
 }
 
-func (s *NotificationService) getNameFromProfile(jsonData interface{}) string {
+// nameFromProfile lee el nombre de un perfil JSONB (usuario o paciente); "Usuario" si no tiene.
+func nameFromProfile(jsonData interface{}) string {
 
 	type Profile struct {
 		FullName  string `json:"full_name"`
@@ -46,6 +49,14 @@ func (s *NotificationService) getNameFromProfile(jsonData interface{}) string {
 	return "Usuario"
 }
 
+// UserDisplayName devuelve el nombre del perfil del usuario o, si no tiene, su correo.
+func UserDisplayName(user domains.User) string {
+	if name := nameFromProfile(user.ProfileData); name != "Usuario" {
+		return name
+	}
+	return user.Email
+}
+
 func (s *NotificationService) getHTMLTemplate(title, bodyContent, actionButton, accentColor string) string {
 	if accentColor == "" {
 		accentColor = "#2563eb"
@@ -174,50 +185,189 @@ func (s *NotificationService) NotifyAccountStatus(userID uuid.UUID, status domai
 	s.createAndNotify(userID, "ACCOUNT_STATUS", subject, "Tu cuenta ha sido "+string(status), html, nil)
 }
 
-func (s *NotificationService) NotifyIncident(patientID uuid.UUID, incidentDetails string) {
+// careTeam carga al paciente y devuelve su nombre para mostrar junto al equipo
+// tratante (creador y colaboradores aceptados), sin duplicados.
+func (s *NotificationService) careTeam(patientID uuid.UUID) (string, map[string]domains.User) {
 	db := database.GetDB()
 	var patient domains.Patient
 	// PersonalInfo es JSONB, se carga automÃ¡ticamente, no requiere Preload
 	db.First(&patient, "id = ?", patientID)
 
 	patientName := "Paciente ID " + patientID.String()
-	pName := s.getNameFromProfile(patient.PersonalInfo)
+	pName := nameFromProfile(patient.PersonalInfo)
 	if pName != "Usuario" {
 		patientName = pName
 	}
 
-	var collaborators []domains.User
-	db.Table("users").
-		Joins("JOIN collaborations ON collaborations.professional_id = users.id").
-		Where("collaborations.patient_id = ? AND collaborations.status = ?", patientID, domains.CollabAccepted).
-		Find(&collaborators)
-
-	var creator domains.User
-	db.First(&creator, "id = ?", patient.CreatorID)
-
-	recipients := append(collaborators, creator)
 	uniqueUsers := make(map[string]domains.User)
-	for _, u := range recipients {
+	for _, u := range CareTeamUsers(db, patientID) {
 		uniqueUsers[u.ID.String()] = u
 	}
 
+	return patientName, uniqueUsers
+}
+
+func (s *NotificationService) NotifyIncident(patientID uuid.UUID, incidentDetails string) {
+	db := database.GetDB()
+	patientName, uniqueUsers := s.careTeam(patientID)
+
 	subject := "⚠️ ALERTA: Incidente con " + patientName
 	summary := "Incidente reportado para " + patientName
 
+	var alerts []domains.PatientAlert
+	db.Where("patient_id = ? AND is_active = ?", patientID, true).
+		Order(domains.SeverityOrderSQL).
+		Find(&alerts)
+
 	body := fmt.Sprintf(`
 		<p style="color:#b91c1c;"><strong>Se ha reportado un evento adverso.</strong></p>
 		<p><strong>Paciente:</strong> %s</p>
+		%s
 		<div style="background-color:#fee2e2; border-left:4px solid #dc2626; padding:15px; margin:20px 0; color:#7f1d1d;">
 			<strong>Detalle:</strong><br/>%s
 		</div>
 		<p>Por favor, revise la bitácora antes de la próxima intervención.</p>
-	`, patientName, incidentDetails)
+	`, html.EscapeString(patientName), s.getAlertsBlock(alerts), html.EscapeString(incidentDetails))
 
 	html := s.getHTMLTemplate("Reporte de Incidente", body, "", "#dc2626")
 
 	for _, professional := range uniqueUsers {
 		s.createAndNotify(professional.ID, "INCIDENT_ALERT", subject, summary, html, &patientID)
 	}
+
+	s.notifyGuardians(patientID, patientName, incidentDetails)
+}
+
+// NotifyIncidentEscalation reenvía con mayor urgencia un incidente que nadie reconoció.
+func (s *NotificationService) NotifyIncidentEscalation(incident domains.Incident, level int, recipients []domains.User) {
+	patientName, _ := s.careTeam(incident.PatientID)
+
+	subject := "🚨 URGENTE: Incidente sin reconocer con " + patientName
+	summary := fmt.Sprintf("Incidente %s sin reconocer para %s (escalación nivel %d)", incident.Severity, patientName, level)
+
+	intro := "Nadie del equipo tratante ha reconocido este incidente."
+	if level >= domains.EscalationManagement {
+		intro = "El incidente sigue sin reconocer tras el recordatorio al equipo tratante. Se requiere su intervención."
+	}
+
+	body := fmt.Sprintf(`
+		<p style="color:#b91c1c;"><strong>%s</strong></p>
+		<p><strong>Paciente:</strong> %s</p>
+		<p><strong>Gravedad:</strong> %s &middot; <strong>Categoría:</strong> %s</p>
+		<p><strong>Reportado:</strong> %s</p>
+		<div style="background-color:#fee2e2; border-left:4px solid #7f1d1d; padding:15px; margin:20px 0; color:#7f1d1d;">
+			<strong>Detalle:</strong><br/>%s
+		</div>
+		<p>Reconozca el incidente en la bitácora para detener la escalación.</p>
+	`, intro, html.EscapeString(patientName), incident.Severity, incident.Category,
+		incident.CreatedAt.Format("02/01/2006 15:04"), html.EscapeString(incident.Details))
+
+	htmlBody := s.getHTMLTemplate("Escalación de Incidente", body, "", "#7f1d1d")
+
+	for _, user := range recipients {
+		s.createAndNotify(user.ID, "INCIDENT_ESCALATION", subject, summary, htmlBody, &incident.ID)
+	}
+}
+
+// NotifyAbnormalVitals avisa al equipo tratante de los signos vitales fuera del
+// umbral configurado para el paciente.
+func (s *NotificationService) NotifyAbnormalVitals(patientID uuid.UUID, readings []domains.AbnormalVital) {
+	if len(readings) == 0 {
+		return
+	}
+
+	patientName, uniqueUsers := s.careTeam(patientID)
+
+	subject := "⚠️ Signos vitales fuera de rango: " + patientName
+	summary := fmt.Sprintf("%d signo(s) vital(es) fuera de rango para %s", len(readings), patientName)
+
+	items := ""
+	for _, r := range readings {
+		items += fmt.Sprintf(`<li><strong>%s:</strong> %g %s (rango %s)</li>`,
+			html.EscapeString(r.Label), r.Value, html.EscapeString(r.Unit), formatThresholdRange(r.Min, r.Max))
+	}
+
+	body := fmt.Sprintf(`
+		<p style="color:#b45309;"><strong>Se registraron signos vitales fuera del rango definido para el paciente.</strong></p>
+		<p><strong>Paciente:</strong> %s</p>
+		<div style="background-color:#fef3c7; border-left:4px solid #d97706; padding:15px; margin:20px 0; color:#78350f;">
+			<ul style="margin:0; padding-left:20px;">%s</ul>
+		</div>
+		<p>Revise la sesión registrada y evalúe si se requiere intervención.</p>
+	`, html.EscapeString(patientName), items)
+
+	htmlBody := s.getHTMLTemplate("Signos Vitales Fuera de Rango", body, "", "#d97706")
+
+	for _, professional := range uniqueUsers {
+		s.createAndNotify(professional.ID, "ABNORMAL_VITALS", subject, summary, htmlBody, &patientID)
+	}
+}
+
+func formatThresholdRange(min, max *float64) string {
+	switch {
+	case min != nil && max != nil:
+		return fmt.Sprintf("%g-%g", *min, *max)
+	case min != nil:
+		return fmt.Sprintf("≥ %g", *min)
+	case max != nil:
+		return fmt.Sprintf("≤ %g", *max)
+	}
+	return "-"
+}
+
+// notifyGuardians avisa por email a los tutores legales que pidieron recibir los incidentes.
+// No son usuarios de la plataforma, por eso no se crea notificación en BD.
+func (s *NotificationService) notifyGuardians(patientID uuid.UUID, patientName string, incidentDetails string) {
+	var guardians []domains.PatientContact
+	database.GetDB().
+		Where("patient_id = ? AND is_legal_guardian = ? AND notify_incidents = ? AND email <> ''", patientID, true, true).
+		Find(&guardians)
+
+	if len(guardians) == 0 {
+		return
+	}
+
+	subject := "Aviso de incidente: " + patientName
+	for _, g := range guardians {
+		body := fmt.Sprintf(`
+			<p>Estimado/a %s,</p>
+			<p>Le informamos que se registró un incidente durante la atención de <strong>%s</strong>.</p>
+			<div style="background-color:#fee2e2; border-left:4px solid #dc2626; padding:15px; margin:20px 0; color:#7f1d1d;">
+				<strong>Detalle:</strong><br/>%s
+			</div>
+			<p>El equipo tratante se pondrá en contacto con usted si es necesario.</p>
+		`, html.EscapeString(g.Name), html.EscapeString(patientName), html.EscapeString(incidentDetails))
+
+		htmlBody := s.getHTMLTemplate("Aviso de Incidente", body, "", "#dc2626")
+
+		go func(to string) {
+			defer func() {
+				if r := recover(); r != nil {
+					slog.Error("PANIC in guardian email goroutine", "recover", r)
+				}
+			}()
+			s.sendRealEmail(to, subject, htmlBody)
+		}(g.Email)
+	}
+}
+
+// getAlertsBlock resume las alertas clínicas activas para que quien responda las vea.
+func (s *NotificationService) getAlertsBlock(alerts []domains.PatientAlert) string {
+	if len(alerts) == 0 {
+		return ""
+	}
+
+	items := ""
+	for _, a := range alerts {
+		items += fmt.Sprintf(`<li><strong>[%s] %s:</strong> %s</li>`,
+			a.Severity, html.EscapeString(a.Title), html.EscapeString(a.Description))
+	}
+
+	return fmt.Sprintf(`
+		<div style="background-color:#fef3c7; border-left:4px solid #d97706; padding:15px; margin:20px 0; color:#78350f;">
+			<strong>Alertas clínicas activas:</strong>
+			<ul style="margin:8px 0 0 0; padding-left:20px;">%s</ul>
+		</div>`, items)
 }
 
 func (s *NotificationService) NotifyCollabInvite(invitedUserID uuid.UUID, patientID uuid.UUID, inviterName string) {
@@ -253,6 +403,97 @@ func (s *NotificationService) NotifyInviteResponse(creatorID uuid.UUID, responde
 	s.createAndNotify(creatorID, "INVITE_RESPONSE", subject, summary, html, nil)
 }
 
+// NotifySessionReturned avisa al autor que su supervisor devolvió la sesión con observaciones.
+func (s *NotificationService) NotifySessionReturned(session domains.Session, supervisor domains.User, comments string) {
+	patientName, _ := s.careTeam(session.PatientID)
+	supervisorName := UserDisplayName(supervisor)
+
+	subject := "Sesión devuelta por su supervisor"
+	summary := supervisorName + " devolvió la sesión del " + session.CreatedAt.Format("02/01/2006") + " de " + patientName
+
+	body := fmt.Sprintf(`
+		<p><strong>%s</strong> revisó su sesión del %s con <strong>%s</strong> y la devolvió para corrección.</p>
+		<div style="background-color:#fffbeb; border-left:4px solid #d97706; padding:15px; margin:20px 0; color:#78350f;">
+			<strong>Observaciones:</strong><br/>%s
+		</div>
+		<p>Al editar la sesión volverá a la cola de co-firma.</p>
+	`, html.EscapeString(supervisorName), session.CreatedAt.Format("02/01/2006"), html.EscapeString(patientName), html.EscapeString(comments))
+
+	htmlBody := s.getHTMLTemplate("Sesión Devuelta", body, "", "#d97706")
+
+	s.createAndNotify(session.ProfessionalID, "SESSION_RETURNED", subject, summary, htmlBody, &session.ID)
+}
+
+// NotifyNewComment avisa a los participantes de un hilo que hay un comentario nuevo.
+func (s *NotificationService) NotifyNewComment(comment domains.Comment, author domains.User, recipients []uuid.UUID) {
+	patientName, _ := s.careTeam(comment.PatientID)
+	authorName := UserDisplayName(author)
+
+	where := "la ficha de " + patientName
+	if comment.SessionID != nil {
+		where = "una sesión de " + patientName
+	}
+
+	subject := "Nuevo comentario sobre " + patientName
+	summary := authorName + " comentó en " + where
+
+	body := fmt.Sprintf(`
+		<p><strong>%s</strong> comentó en %s:</p>
+		<div style="background-color:#eff6ff; border-left:4px solid #2563eb; padding:15px; margin:20px 0;">
+			%s
+		</div>
+	`, html.EscapeString(authorName), html.EscapeString(where), html.EscapeString(comment.Body))
+
+	htmlBody := s.getHTMLTemplate("Nuevo Comentario", body, "", "#2563eb")
+
+	for _, userID := range recipients {
+		s.createAndNotify(userID, "NEW_COMMENT", subject, summary, htmlBody, &comment.ID)
+	}
+}
+
+// frontendLink arma un botón hacia una ruta del frontend; vacío si FRONTEND_URL no está configurada.
+func (s *NotificationService) frontendLink(path, label string) string {
+	if s.cfg.FrontendURL == "" {
+		return ""
+	}
+	url := strings.TrimRight(s.cfg.FrontendURL, "/") + path
+	return fmt.Sprintf(`<a href="%s" style="background-color:#2563eb; color:white; padding:10px 20px; text-decoration:none; border-radius:5px;">%s</a>`,
+		html.EscapeString(url), label)
+}
+
+// NotifyMentions avisa a cada usuario mencionado con un enlace a la sesión o ficha.
+func (s *NotificationService) NotifyMentions(mentions []domains.Mention, author domains.User) {
+	if len(mentions) == 0 {
+		return
+	}
+
+	patientName, _ := s.careTeam(mentions[0].PatientID)
+	authorName := UserDisplayName(author)
+
+	for _, m := range mentions {
+		path := "/patients/" + m.PatientID.String()
+		where := "la ficha de " + patientName
+		if m.SessionID != nil {
+			path += "/sessions/" + m.SessionID.String()
+			where = "una sesión de " + patientName
+		}
+
+		subject := authorName + " te mencionó"
+		summary := authorName + " te mencionó en " + where
+
+		body := fmt.Sprintf(`
+			<p><strong>%s</strong> te mencionó en %s:</p>
+			<div style="background-color:#eff6ff; border-left:4px solid #2563eb; padding:15px; margin:20px 0;">
+				%s
+			</div>
+		`, html.EscapeString(authorName), html.EscapeString(where), html.EscapeString(m.Excerpt))
+
+		htmlBody := s.getHTMLTemplate("Nueva Mención", body, s.frontendLink(path, "Ver en la bitácora"), "#2563eb")
+
+		s.createAndNotify(m.MentionedUserID, "MENTION", subject, summary, htmlBody, &m.ID)
+	}
+}
+
 func (s *NotificationService) NotifyTicketReply(userID uuid.UUID, ticketSubject string, reply string) {
 	subject := "Respuesta a tu Ticket de Soporte"
 	summary := "Admin ha respondido a: " + ticketSubject
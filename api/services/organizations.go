@@ -0,0 +1,50 @@
+package services
+
+import (
+	"bitacora-medica-backend/api/domains"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// UserOrganizationIDs devuelve una subconsulta con las organizaciones del usuario.
+func UserOrganizationIDs(db *gorm.DB, userID uuid.UUID) *gorm.DB {
+	return db.Session(&gorm.Session{NewDB: true}).
+		Model(&domains.OrganizationMember{}).
+		Select("organization_id").
+		Where("user_id = ?", userID)
+}
+
+// IsOrganizationMember indica si el usuario pertenece a la organización,
+// opcionalmente exigiendo un rol concreto.
+func IsOrganizationMember(db *gorm.DB, userID uuid.UUID, orgID uuid.UUID, roles ...domains.OrgRole) bool {
+	query := db.Session(&gorm.Session{NewDB: true}).
+		Model(&domains.OrganizationMember{}).
+		Where("user_id = ? AND organization_id = ?", userID, orgID)
+	if len(roles) > 0 {
+		query = query.Where("role IN ?", roles)
+	}
+
+	var count int64
+	query.Count(&count)
+	return count > 0
+}
+
+// VisibleTags filtra las etiquetas que el usuario puede ver y usar:
+// las personales propias y las de sus organizaciones.
+func VisibleTags(db *gorm.DB, userID uuid.UUID) *gorm.DB {
+	base := db.Session(&gorm.Session{NewDB: true})
+
+	return base.Model(&domains.Tag{}).
+		Where(base.Where("scope = ? AND owner_id = ?", domains.TagScopeUser, userID).
+			Or("scope = ? AND organization_id IN (?)", domains.TagScopeOrganization, UserOrganizationIDs(db, userID)))
+}
+
+// VisibleCohorts filtra las cohortes propias y las compartidas con las organizaciones del usuario.
+func VisibleCohorts(db *gorm.DB, userID uuid.UUID) *gorm.DB {
+	base := db.Session(&gorm.Session{NewDB: true})
+
+	return base.Model(&domains.SavedCohort{}).
+		Where(base.Where("owner_id = ?", userID).
+			Or("organization_id IN (?)", UserOrganizationIDs(db, userID)))
+}
@@ -0,0 +1,37 @@
+package services
+
+import (
+	"bitacora-medica-backend/api/domains"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AccessiblePatientIDs devuelve una subconsulta con los IDs de los pacientes
+// creados por el usuario o compartidos con él mediante una colaboración ACEPTADA.
+func AccessiblePatientIDs(db *gorm.DB, userID uuid.UUID) *gorm.DB {
+	base := db.Session(&gorm.Session{NewDB: true})
+
+	return base.Model(&domains.Patient{}).
+		Select("id").
+		Where(base.Where("creator_id = ?", userID).
+			Or("id IN (?)", base.Table("collaborations").
+				Select("patient_id").
+				Where("professional_id = ? AND status = ?", userID, domains.CollabAccepted)))
+}
+
+// CanAccessPatient indica si el usuario forma parte del equipo del paciente.
+// Los administradores tienen acceso a todos los pacientes.
+func CanAccessPatient(db *gorm.DB, user domains.User, patientID uuid.UUID) bool {
+	if user.Role == domains.RoleAdmin {
+		return true
+	}
+
+	var count int64
+	db.Session(&gorm.Session{NewDB: true}).
+		Model(&domains.Patient{}).
+		Where("id = ? AND id IN (?)", patientID, AccessiblePatientIDs(db, user.ID)).
+		Count(&count)
+
+	return count > 0
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"bitacora-medica-backend/api/domains"
+	"bitacora-medica-backend/api/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TaggedPatientIDs devuelve una subconsulta con los pacientes que tienen TODAS las etiquetas indicadas.
+func TaggedPatientIDs(db *gorm.DB, tagIDs []uuid.UUID) *gorm.DB {
+	return db.Session(&gorm.Session{NewDB: true}).
+		Model(&domains.PatientTag{}).
+		Select("patient_id").
+		Where("tag_id IN ?", tagIDs).
+		Group("patient_id").
+		Having("count(DISTINCT tag_id) = ?", len(tagIDs))
+}
+
+// ApplyPatientFilters aplica los filtros de una cohorte sobre una consulta de pacientes.
+func ApplyPatientFilters(query *gorm.DB, filters domains.CohortFilters) (*gorm.DB, error) {
+	if filters.Status != "" {
+		if !domains.PatientStatus(filters.Status).IsValid() {
+			return nil, fmt.Errorf("invalid status: %s", filters.Status)
+		}
+		query = query.Where("status = ?", filters.Status)
+	}
+
+	if len(filters.TagIDs) > 0 {
+		tagIDs, err := utils.ParseUUIDs(filters.TagIDs)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("id IN (?)", TaggedPatientIDs(query, tagIDs))
+	}
+
+	return query, nil
+}
+
+// CohortPatientIDs devuelve una subconsulta con los pacientes que cumplen los filtros de la cohorte.
+func CohortPatientIDs(db *gorm.DB, cohort domains.SavedCohort) (*gorm.DB, error) {
+	var filters domains.CohortFilters
+	if err := json.Unmarshal(cohort.Filters, &filters); err != nil {
+		return nil, fmt.Errorf("invalid cohort filters: %w", err)
+	}
+
+	base := db.Session(&gorm.Session{NewDB: true}).Model(&domains.Patient{}).Select("id")
+	return ApplyPatientFilters(base, filters)
+}
+
+// FindVisibleCohort busca una cohorte que el usuario pueda usar.
+func FindVisibleCohort(db *gorm.DB, user domains.User, cohortID string) (domains.SavedCohort, error) {
+	var cohort domains.SavedCohort
+	query := db.Session(&gorm.Session{NewDB: true})
+	if user.Role != domains.RoleAdmin {
+		query = VisibleCohorts(db, user.ID)
+	}
+	err := query.First(&cohort, "id = ?", cohortID).Error
+	return cohort, err
+}
+
+// CohortScope resuelve una cohorte visible para el usuario y devuelve la subconsulta de sus pacientes.
+// Devuelve gorm.ErrRecordNotFound si la cohorte no existe o no es visible.
+func CohortScope(db *gorm.DB, user domains.User, cohortID string) (*gorm.DB, error) {
+	cohort, err := FindVisibleCohort(db, user, cohortID)
+	if err != nil {
+		return nil, err
+	}
+	return CohortPatientIDs(db, cohort)
+}
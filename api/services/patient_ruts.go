@@ -0,0 +1,48 @@
+package services
+
+import (
+	"errors"
+
+	"bitacora-medica-backend/api/domains"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// ExistingPatientRUTs devuelve cuáles de los RUTs (ya normalizados) pertenecen a pacientes
+// registrados. El valor indica si el paciente es del equipo del usuario, para no revelar
+// los datos de pacientes de otros equipos.
+func ExistingPatientRUTs(db *gorm.DB, user domains.User, normalizedRUTs []string) (map[string]bool, error) {
+	existing := make(map[string]bool)
+	if len(normalizedRUTs) == 0 {
+		return existing, nil
+	}
+
+	var found []struct {
+		RUT        string
+		Accessible bool
+	}
+	query := db.Session(&gorm.Session{NewDB: true}).
+		Table("patients").
+		Where("deleted_at IS NULL").
+		Where(domains.PatientRUTSQL+" IN ?", normalizedRUTs)
+	if user.Role == domains.RoleAdmin {
+		query = query.Select(domains.PatientRUTSQL + " AS rut, true AS accessible")
+	} else {
+		query = query.Select(domains.PatientRUTSQL+" AS rut, id IN (?) AS accessible", AccessiblePatientIDs(db, user.ID))
+	}
+	if err := query.Scan(&found).Error; err != nil {
+		return nil, err
+	}
+
+	for _, f := range found {
+		existing[f.RUT] = f.Accessible
+	}
+	return existing, nil
+}
+
+// IsUniqueViolation indica si el error viene de un índice único (ej: RUT de paciente repetido).
+func IsUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
@@ -0,0 +1,30 @@
+package services
+
+import (
+	"bitacora-medica-backend/api/domains"
+
+	"gorm.io/gorm"
+)
+
+// CountPatientsByStatus agrupa por estado los pacientes de la consulta recibida.
+// Todos los estados aparecen en el resultado, aunque su conteo sea cero.
+func CountPatientsByStatus(query *gorm.DB) map[string]int64 {
+	counts := map[string]int64{
+		string(domains.PatientActive):     0,
+		string(domains.PatientOnHold):     0,
+		string(domains.PatientDischarged): 0,
+		string(domains.PatientDeceased):   0,
+	}
+
+	type statusCount struct {
+		Status string
+		Count  int64
+	}
+	var results []statusCount
+	query.Select("status, count(*) as count").Group("status").Scan(&results)
+
+	for _, r := range results {
+		counts[r.Status] = r.Count
+	}
+	return counts
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"bitacora-medica-backend/api/domains"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// VisibleSessionTemplates filtra las plantillas propias y las de las organizaciones del usuario.
+func VisibleSessionTemplates(db *gorm.DB, userID uuid.UUID) *gorm.DB {
+	base := db.Session(&gorm.Session{NewDB: true})
+
+	return base.Model(&domains.SessionTemplate{}).
+		Where(base.Where("owner_id = ?", userID).
+			Or("organization_id IN (?)", UserOrganizationIDs(db, userID)))
+}
+
+// ValidateTemplateFields revisa la definición de los campos extra de una plantilla.
+func ValidateTemplateFields(fields []domains.TemplateField) error {
+	seen := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		if seen[f.Key] {
+			return fmt.Errorf("duplicated extra field key: %s", f.Key)
+		}
+		seen[f.Key] = true
+
+		if f.Type == domains.FieldSelect && len(f.Options) == 0 {
+			return fmt.Errorf("extra field %s of type SELECT requires options", f.Key)
+		}
+		if f.Min != nil && f.Max != nil && *f.Min > *f.Max {
+			return fmt.Errorf("extra field %s: min cannot be greater than max", f.Key)
+		}
+	}
+	return nil
+}
+
+// ApplySessionTemplate valida la sesión contra la plantilla: campos estándar
+// obligatorios y campos extra. Completa el plan de intervención por defecto y
+// devuelve los campos extra serializados.
+func ApplySessionTemplate(template domains.SessionTemplate, input *domains.CreateSessionInput) (datatypes.JSON, error) {
+	if input.InterventionPlan == "" {
+		input.InterventionPlan = template.DefaultInterventionPlan
+	}
+
+	filled := map[string]bool{
+		"vitals":              len(input.Vitals) > 0,
+		"achievements":        input.Achievements != "",
+		"patient_performance": input.PatientPerformance != "",
+		"photos":              len(input.Photos) > 0,
+		"next_session_notes":  input.NextSessionNotes != "",
+	}
+	for _, field := range template.RequiredFields {
+		if !filled[field] {
+			return nil, fmt.Errorf("%s is required by the template %s", field, template.Name)
+		}
+	}
+
+	var fields []domains.TemplateField
+	if len(template.ExtraFields) > 0 {
+		if err := json.Unmarshal(template.ExtraFields, &fields); err != nil {
+			return nil, fmt.Errorf("template %s has invalid extra fields", template.Name)
+		}
+	}
+
+	extra, err := validateExtraFields(fields, input.ExtraFields)
+	if err != nil {
+		return nil, err
+	}
+
+	extraJSON, _ := json.Marshal(extra)
+	return datatypes.JSON(extraJSON), nil
+}
+
+func validateExtraFields(fields []domains.TemplateField, values map[string]interface{}) (map[string]interface{}, error) {
+	known := make(map[string]domains.TemplateField, len(fields))
+	for _, f := range fields {
+		known[f.Key] = f
+	}
+	for key := range values {
+		if _, found := known[key]; !found {
+			return nil, fmt.Errorf("unknown extra field: %s", key)
+		}
+	}
+
+	extra := make(map[string]interface{}, len(values))
+	for _, f := range fields {
+		value, present := values[f.Key]
+		if !present || value == nil || value == "" {
+			if f.Required {
+				return nil, fmt.Errorf("extra field %s is required", f.Key)
+			}
+			continue
+		}
+
+		switch f.Type {
+		case domains.FieldText:
+			if _, ok := value.(string); !ok {
+				return nil, fmt.Errorf("extra field %s must be text", f.Key)
+			}
+		case domains.FieldNumber:
+			number, ok := value.(float64)
+			if !ok {
+				return nil, fmt.Errorf("extra field %s must be a number", f.Key)
+			}
+			if (f.Min != nil && number < *f.Min) || (f.Max != nil && number > *f.Max) {
+				return nil, fmt.Errorf("extra field %s out of range", f.Key)
+			}
+		case domains.FieldBoolean:
+			if _, ok := value.(bool); !ok {
+				return nil, fmt.Errorf("extra field %s must be true or false", f.Key)
+			}
+		case domains.FieldSelect:
+			text, _ := value.(string)
+			valid := false
+			for _, option := range f.Options {
+				if option == text {
+					valid = true
+					break
+				}
+			}
+			if !valid {
+				return nil, fmt.Errorf("extra field %s must be one of %v", f.Key, f.Options)
+			}
+		case domains.FieldDate:
+			text, _ := value.(string)
+			if _, err := time.Parse("2006-01-02", text); err != nil {
+				return nil, fmt.Errorf("extra field %s must be a date (YYYY-MM-DD)", f.Key)
+			}
+		}
+		extra[f.Key] = value
+	}
+	return extra, nil
+}
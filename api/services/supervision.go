@@ -0,0 +1,40 @@
+package services
+
+import (
+	"bitacora-medica-backend/api/domains"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TraineeIDs devuelve una subconsulta con los usuarios supervisados por supervisorID.
+func TraineeIDs(db *gorm.DB, supervisorID uuid.UUID) *gorm.DB {
+	return db.Session(&gorm.Session{NewDB: true}).
+		Model(&domains.Supervision{}).
+		Select("trainee_id").
+		Where("supervisor_id = ?", supervisorID)
+}
+
+// IsSupervisorOf indica si supervisorID supervisa a traineeID.
+func IsSupervisorOf(db *gorm.DB, supervisorID, traineeID uuid.UUID) bool {
+	var count int64
+	db.Session(&gorm.Session{NewDB: true}).
+		Model(&domains.Supervision{}).
+		Where("supervisor_id = ? AND trainee_id = ?", supervisorID, traineeID).
+		Count(&count)
+	return count > 0
+}
+
+// InitialCosignStatus es el estado de co-firma de una sesión nueva: PENDING si su
+// autor tiene supervisor, NOT_REQUIRED en otro caso.
+func InitialCosignStatus(db *gorm.DB, authorID uuid.UUID) domains.CosignStatus {
+	var count int64
+	db.Session(&gorm.Session{NewDB: true}).
+		Model(&domains.Supervision{}).
+		Where("trainee_id = ?", authorID).
+		Count(&count)
+	if count > 0 {
+		return domains.CosignPending
+	}
+	return domains.CosignNotRequired
+}
@@ -0,0 +1,15 @@
+package services
+
+import (
+	"bitacora-medica-backend/api/domains"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// FindSyncReceipt busca el resultado de una operación offline ya procesada del usuario.
+func FindSyncReceipt(db *gorm.DB, userID uuid.UUID, clientID uuid.UUID) (domains.SyncReceipt, bool) {
+	var receipt domains.SyncReceipt
+	err := db.Where("client_id = ? AND user_id = ?", clientID, userID).Limit(1).Find(&receipt).Error
+	return receipt, err == nil && receipt.ClientID != uuid.Nil
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"strings"
+
+	"bitacora-medica-backend/api/domains"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// timelineSources define la consulta de cada tipo de evento. Todas devuelven las mismas
+// columnas con alias (type, source_id, occurred_at, actor_id, summary), ya que cualquiera
+// puede quedar primera en el UNION ALL. Cada una recibe el ID del paciente como único parámetro.
+var timelineSources = map[domains.TimelineEventType]string{
+	domains.TimelineSession: `
+		SELECT 'SESSION' AS type, id AS source_id, created_at AS occurred_at,
+			professional_id AS actor_id, left(description, 200) AS summary
+		FROM sessions WHERE patient_id = ? AND deleted_at IS NULL`,
+	domains.TimelineIncident: `
+		SELECT 'INCIDENT' AS type, id AS source_id, created_at AS occurred_at,
+			professional_id AS actor_id, left(incident_details, 200) AS summary
+		FROM sessions WHERE patient_id = ? AND deleted_at IS NULL AND has_incident = true`,
+	domains.TimelineDocument: `
+		SELECT 'DOCUMENT' AS type, id AS source_id, date AS occurred_at,
+			NULL::uuid AS actor_id, name || ' (' || category || ')' AS summary
+		FROM patient_documents WHERE patient_id = ? AND deleted_at IS NULL`,
+	domains.TimelineReport: `
+		SELECT 'REPORT' AS type, id AS source_id, created_at AS occurred_at, author_id AS actor_id,
+			'Informe ' || to_char(date_range_start, 'YYYY-MM-DD') || ' / ' || to_char(date_range_end, 'YYYY-MM-DD') AS summary
+		FROM professional_reports WHERE patient_id = ?`,
+	domains.TimelineCollaborationJoined: `
+		SELECT 'COLLABORATION_JOINED' AS type, id AS source_id, COALESCE(accepted_at, updated_at) AS occurred_at,
+			professional_id AS actor_id, '' AS summary
+		FROM collaborations WHERE patient_id = ? AND (accepted_at IS NOT NULL OR status = 'ACCEPTED')`,
+	domains.TimelineCollaborationRevoked: `
+		SELECT 'COLLABORATION_REVOKED' AS type, id AS source_id, COALESCE(revoked_at, updated_at) AS occurred_at,
+			professional_id AS actor_id, '' AS summary
+		FROM collaborations WHERE patient_id = ? AND status = 'REVOKED'`,
+	domains.TimelineProfileChange: `
+		SELECT 'PROFILE_CHANGE' AS type, id AS source_id, created_at AS occurred_at,
+			changed_by_id AS actor_id, field AS summary
+		FROM patient_profile_changes WHERE patient_id = ?`,
+	domains.TimelineStatusChange: `
+		SELECT 'STATUS_CHANGE' AS type, id AS source_id, created_at AS occurred_at,
+			changed_by_id AS actor_id, from_status || ' -> ' || to_status AS summary
+		FROM patient_status_histories WHERE patient_id = ?`,
+}
+
+// TimelineEventKey es el desempate estable entre eventos con la misma fecha
+// (una sesión con incidente genera dos eventos con el mismo source_id).
+func TimelineEventKey(e domains.TimelineEvent) string {
+	return string(e.Type) + ":" + e.SourceID.String()
+}
+
+// PatientTimeline devuelve los eventos del paciente, por defecto del más reciente al más antiguo.
+// Se pide un evento extra para saber si hay más páginas.
+func PatientTimeline(db *gorm.DB, patientID uuid.UUID, filter domains.TimelineFilter) ([]domains.TimelineEvent, bool, error) {
+	types := filter.Types
+	if len(types) == 0 {
+		types = domains.TimelineEventTypes
+	}
+
+	branches := make([]string, 0, len(types))
+	args := make([]interface{}, 0, len(types)+5)
+	for _, t := range types {
+		branches = append(branches, timelineSources[t])
+		args = append(args, patientID)
+	}
+
+	var where []string
+	if filter.From != nil {
+		where = append(where, "occurred_at >= ?")
+		args = append(args, *filter.From)
+	}
+	if filter.To != nil {
+		where = append(where, "occurred_at < ?")
+		args = append(args, *filter.To)
+	}
+	op, direction := "<", "DESC"
+	if filter.Ascending {
+		op, direction = ">", "ASC"
+	}
+	if filter.AfterTime != nil {
+		where = append(where, "(occurred_at, type || ':' || source_id::text) "+op+" (?, ?)")
+		args = append(args, *filter.AfterTime, filter.AfterKey)
+	}
+
+	sql := "SELECT * FROM (" + strings.Join(branches, "\nUNION ALL\n") + "\n) AS timeline"
+	if len(where) > 0 {
+		sql += " WHERE " + strings.Join(where, " AND ")
+	}
+	sql += " ORDER BY occurred_at " + direction + ", type || ':' || source_id::text " + direction + " LIMIT ?"
+	args = append(args, filter.Limit+1)
+
+	var events []domains.TimelineEvent
+	if err := db.Raw(sql, args...).Scan(&events).Error; err != nil {
+		return nil, false, err
+	}
+
+	hasMore := len(events) > filter.Limit
+	if hasMore {
+		events = events[:filter.Limit]
+	}
+
+	// Autores de los eventos, en una sola consulta
+	actorIDs := make([]uuid.UUID, 0, len(events))
+	for _, e := range events {
+		if e.ActorID != nil {
+			actorIDs = append(actorIDs, *e.ActorID)
+		}
+	}
+	if len(actorIDs) > 0 {
+		var actors []domains.User
+		db.Where("id IN ?", actorIDs).Find(&actors)
+		byID := make(map[uuid.UUID]domains.User, len(actors))
+		for _, a := range actors {
+			byID[a.ID] = a
+		}
+		for i := range events {
+			if events[i].ActorID == nil {
+				continue
+			}
+			if actor, ok := byID[*events[i].ActorID]; ok {
+				events[i].Actor = &actor
+			}
+		}
+	}
+
+	return events, hasMore, nil
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"bitacora-medica-backend/api/domains"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// bloodPressureKey permite enviar la presión como "120/80" en vez de dos campos.
+const bloodPressureKey = "blood_pressure"
+
+// NormalizeVitals valida los signos vitales contra el catálogo y los devuelve
+// como números. Acepta números o texto numérico ("36,5").
+func NormalizeVitals(raw map[string]interface{}) (map[domains.VitalKey]float64, error) {
+	values := make(map[string]interface{}, len(raw))
+	for key, value := range raw {
+		if key != bloodPressureKey {
+			values[key] = value
+			continue
+		}
+		text, ok := value.(string)
+		parts := strings.Split(text, "/")
+		if !ok || len(parts) != 2 {
+			return nil, fmt.Errorf("%s must be formatted as systolic/diastolic (e.g. 120/80)", bloodPressureKey)
+		}
+		values[string(domains.VitalBPSystolic)] = parts[0]
+		values[string(domains.VitalBPDiastolic)] = parts[1]
+	}
+
+	vitals := make(map[domains.VitalKey]float64, len(values))
+	for key, value := range values {
+		def, found := domains.LookupVital(domains.VitalKey(key))
+		if !found {
+			return nil, fmt.Errorf("unknown vital sign: %s", key)
+		}
+		if value == nil {
+			continue
+		}
+
+		number, err := vitalNumber(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a number expressed in %s", key, def.Unit)
+		}
+		if number < def.Min || number > def.Max {
+			return nil, fmt.Errorf("%s out of plausible range (%g-%g %s)", key, def.Min, def.Max, def.Unit)
+		}
+		if def.Integer {
+			number = math.Round(number)
+		}
+		vitals[def.Key] = number
+	}
+
+	systolic, hasSystolic := vitals[domains.VitalBPSystolic]
+	diastolic, hasDiastolic := vitals[domains.VitalBPDiastolic]
+	if hasSystolic && hasDiastolic && diastolic >= systolic {
+		return nil, fmt.Errorf("%s must be lower than %s", domains.VitalBPDiastolic, domains.VitalBPSystolic)
+	}
+
+	return vitals, nil
+}
+
+func vitalNumber(value interface{}) (float64, error) {
+	var number float64
+	switch v := value.(type) {
+	case float64:
+		number = v
+	case string:
+		parsed, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(v), ",", "."), 64)
+		if err != nil {
+			return 0, err
+		}
+		number = parsed
+	default:
+		return 0, fmt.Errorf("not a number")
+	}
+	// ParseFloat acepta "NaN" e "Inf", que no pasan por las comparaciones de rango
+	if math.IsNaN(number) || math.IsInf(number, 0) {
+		return 0, fmt.Errorf("not a finite number")
+	}
+	return number, nil
+}
+
+// AbnormalVitals compara los valores con los umbrales configurados para el paciente.
+func AbnormalVitals(db *gorm.DB, patientID uuid.UUID, vitals map[domains.VitalKey]float64) []domains.AbnormalVital {
+	if len(vitals) == 0 {
+		return nil
+	}
+
+	var thresholds []domains.VitalThreshold
+	db.Where("patient_id = ?", patientID).Find(&thresholds)
+
+	var abnormal []domains.AbnormalVital
+	for _, t := range thresholds {
+		value, found := vitals[t.VitalKey]
+		if !found {
+			continue
+		}
+		if (t.Min != nil && value < *t.Min) || (t.Max != nil && value > *t.Max) {
+			def, _ := domains.LookupVital(t.VitalKey)
+			abnormal = append(abnormal, domains.AbnormalVital{
+				Key:   t.VitalKey,
+				Label: def.Label,
+				Unit:  def.Unit,
+				Value: value,
+				Min:   t.Min,
+				Max:   t.Max,
+			})
+		}
+	}
+	return abnormal
+}
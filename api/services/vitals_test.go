@@ -0,0 +1,63 @@
+package services
+
+import (
+	"testing"
+
+	"bitacora-medica-backend/api/domains"
+)
+
+func TestNormalizeVitals(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     map[string]interface{}
+		want    map[domains.VitalKey]float64
+		wantErr bool
+	}{
+		{
+			name: "numbers and numeric text",
+			raw:  map[string]interface{}{"heart_rate": 72.4, "temperature": "36,5"},
+			want: map[domains.VitalKey]float64{domains.VitalHeartRate: 72, domains.VitalTemperature: 36.5},
+		},
+		{
+			name: "blood pressure as text",
+			raw:  map[string]interface{}{"blood_pressure": "120/80"},
+			want: map[domains.VitalKey]float64{domains.VitalBPSystolic: 120, domains.VitalBPDiastolic: 80},
+		},
+		{
+			name: "null values are skipped",
+			raw:  map[string]interface{}{"heart_rate": nil},
+			want: map[domains.VitalKey]float64{},
+		},
+		{name: "NaN text", raw: map[string]interface{}{"heart_rate": "NaN"}, wantErr: true},
+		{name: "Inf text", raw: map[string]interface{}{"heart_rate": "+Inf"}, wantErr: true},
+		{name: "not a number", raw: map[string]interface{}{"heart_rate": "fast"}, wantErr: true},
+		{name: "unsupported type", raw: map[string]interface{}{"heart_rate": true}, wantErr: true},
+		{name: "out of range", raw: map[string]interface{}{"heart_rate": 400.0}, wantErr: true},
+		{name: "unknown vital", raw: map[string]interface{}{"mood": 5.0}, wantErr: true},
+		{name: "malformed blood pressure", raw: map[string]interface{}{"blood_pressure": "120"}, wantErr: true},
+		{name: "diastolic above systolic", raw: map[string]interface{}{"blood_pressure": "80/120"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeVitals(tt.raw)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for key, value := range tt.want {
+				if got[key] != value {
+					t.Errorf("%s = %v, want %v", key, got[key], value)
+				}
+			}
+		})
+	}
+}
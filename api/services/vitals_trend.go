@@ -0,0 +1,82 @@
+package services
+
+import (
+	"time"
+
+	"bitacora-medica-backend/api/domains"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type vitalTrendRow struct {
+	Key    domains.VitalKey
+	Bucket time.Time
+	Min    float64
+	Max    float64
+	Avg    float64
+	Count  int
+}
+
+// VitalTrends arma las series de tiempo de los signos vitales del paciente en [from, to),
+// calculadas en SQL sobre sessions.vitals. Los valores no numéricos (sesiones previas
+// al catálogo) se ignoran.
+func VitalTrends(db *gorm.DB, patientID uuid.UUID, keys []domains.VitalKey, from, to time.Time, interval domains.VitalTrendInterval) ([]domains.VitalSeries, error) {
+	if len(keys) == 0 {
+		for _, def := range domains.VitalsCatalog {
+			keys = append(keys, def.Key)
+		}
+	}
+
+	// El intervalo viene validado, se interpola para poder agrupar por la misma expresión
+	bucket := "s.created_at"
+	if interval == domains.VitalTrendDay || interval == domains.VitalTrendWeek {
+		bucket = "date_trunc('" + string(interval) + "', s.created_at)"
+	}
+
+	// Sesiones sin signos vitales guardan 'null': jsonb_each falla si no es un objeto
+	query := db.Table("sessions s, jsonb_each(CASE WHEN jsonb_typeof(s.vitals) = 'object' THEN s.vitals ELSE '{}'::jsonb END) v").
+		Select(`v.key AS key, `+bucket+` AS bucket,
+			min((v.value #>> '{}')::numeric) AS min,
+			max((v.value #>> '{}')::numeric) AS max,
+			round(avg((v.value #>> '{}')::numeric), 2) AS avg,
+			count(*) AS count`).
+		Where("s.patient_id = ? AND s.deleted_at IS NULL", patientID).
+		Where("s.created_at >= ? AND s.created_at < ?", from, to).
+		Where("jsonb_typeof(v.value) = 'number'").
+		Where("v.key IN ?", keys).
+		Group("v.key, " + bucket).
+		Order("v.key, bucket")
+
+	var rows []vitalTrendRow
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	byKey := make(map[domains.VitalKey][]domains.VitalTrendPoint)
+	for _, r := range rows {
+		byKey[r.Key] = append(byKey[r.Key], domains.VitalTrendPoint{
+			Bucket: r.Bucket,
+			Min:    r.Min,
+			Max:    r.Max,
+			Avg:    r.Avg,
+			Count:  r.Count,
+		})
+	}
+
+	series := make([]domains.VitalSeries, 0, len(keys))
+	for _, key := range keys {
+		def, _ := domains.LookupVital(key)
+		points := byKey[key]
+		if points == nil {
+			points = []domains.VitalTrendPoint{}
+		}
+		series = append(series, domains.VitalSeries{
+			Key:    key,
+			Label:  def.Label,
+			Unit:   def.Unit,
+			Points: points,
+		})
+	}
+	return series, nil
+}
@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+// mentionPattern reconoce "@usuario" al inicio del texto o tras un carácter que no
+// forma parte de una palabra, para no confundir correos electrónicos con menciones.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_.@])@([\p{L}\p{N}_][\p{L}\p{N}_.\-]*)`)
+
+var accentFolder = strings.NewReplacer(
+	"á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ü", "u", "ñ", "n",
+)
+
+// NormalizeHandle pasa un identificador a minúsculas y sin tildes, para comparar
+// "@María" con "maria".
+func NormalizeHandle(value string) string {
+	return accentFolder.Replace(strings.ToLower(strings.TrimSpace(value)))
+}
+
+// ParseMentions devuelve los identificadores mencionados en el texto, normalizados
+// y sin repetir, en el orden en que aparecen.
+func ParseMentions(text string) []string {
+	var handles []string
+	seen := make(map[string]bool)
+	for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		handle := NormalizeHandle(strings.TrimRight(match[1], ".-"))
+		if handle == "" || seen[handle] {
+			continue
+		}
+		seen[handle] = true
+		handles = append(handles, handle)
+	}
+	return handles
+}
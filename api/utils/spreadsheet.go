@@ -0,0 +1,230 @@
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	maxXLSXColumns  = 16384    // Última columna de Excel: XFD
+	maxXLSXPartSize = 50 << 20 // Tamaño máximo descomprimido de cada parte XML
+)
+
+// ReadCSV lee todas las filas de un CSV. Acepta "," o ";" como separador
+// (Excel en español exporta con punto y coma) y descarta el BOM UTF-8.
+func ReadCSV(r io.Reader) ([][]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	firstLine, _, _ := bytes.Cut(data, []byte("\n"))
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		reader.Comma = ';'
+	}
+
+	return reader.ReadAll()
+}
+
+// Estructuras mínimas del formato OOXML que necesitamos leer.
+type xlsxWorkbook struct {
+	Sheets []struct {
+		RID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxRichText struct {
+	T string `xml:"t"`
+	R []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (rt xlsxRichText) text() string {
+	if len(rt.R) == 0 {
+		return rt.T
+	}
+	var sb strings.Builder
+	for _, r := range rt.R {
+		sb.WriteString(r.T)
+	}
+	return sb.String()
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxRichText `xml:"si"`
+}
+
+type xlsxSheet struct {
+	Rows []struct {
+		Cells []struct {
+			Ref    string       `xml:"r,attr"`
+			Type   string       `xml:"t,attr"`
+			Value  string       `xml:"v"`
+			Inline xlsxRichText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// ReadXLSX lee la primera hoja de un libro XLSX como filas de texto.
+// Es un lector mínimo: ignora estilos y fórmulas (usa el último valor calculado),
+// por lo que las fechas llegan como número de serie (ver ExcelSerialToDate).
+func ReadXLSX(r io.ReaderAt, size int64) ([][]string, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("invalid XLSX file: %w", err)
+	}
+
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, f := range archive.File {
+		files[f.Name] = f
+	}
+
+	var shared xlsxSharedStrings
+	if f, ok := files["xl/sharedStrings.xml"]; ok {
+		if err := decodeZipXML(f, &shared); err != nil {
+			return nil, err
+		}
+	}
+
+	sheetFile, ok := files[firstSheetPath(files)]
+	if !ok {
+		return nil, errors.New("invalid XLSX file: worksheet not found")
+	}
+
+	var sheet xlsxSheet
+	if err := decodeZipXML(sheetFile, &sheet); err != nil {
+		return nil, err
+	}
+
+	rows := make([][]string, 0, len(sheet.Rows))
+	for _, row := range sheet.Rows {
+		var values []string
+		for i, cell := range row.Cells {
+			col := i
+			if cell.Ref != "" {
+				var err error
+				if col, err = columnIndex(cell.Ref); err != nil {
+					return nil, err
+				}
+			}
+			if col >= maxXLSXColumns {
+				return nil, fmt.Errorf("invalid XLSX file: too many columns (max %d)", maxXLSXColumns)
+			}
+			for len(values) <= col {
+				values = append(values, "")
+			}
+
+			switch cell.Type {
+			case "s":
+				idx, err := strconv.Atoi(cell.Value)
+				if err == nil && idx >= 0 && idx < len(shared.Items) {
+					values[col] = shared.Items[idx].text()
+				}
+			case "inlineStr":
+				values[col] = cell.Inline.text()
+			default:
+				values[col] = cell.Value
+			}
+		}
+		rows = append(rows, values)
+	}
+
+	return rows, nil
+}
+
+// firstSheetPath resuelve la ruta de la primera hoja a partir del workbook y sus relaciones.
+func firstSheetPath(files map[string]*zip.File) string {
+	const fallback = "xl/worksheets/sheet1.xml"
+
+	var workbook xlsxWorkbook
+	var rels xlsxRelationships
+	wbFile, ok1 := files["xl/workbook.xml"]
+	relsFile, ok2 := files["xl/_rels/workbook.xml.rels"]
+	if !ok1 || !ok2 || decodeZipXML(wbFile, &workbook) != nil || decodeZipXML(relsFile, &rels) != nil {
+		return fallback
+	}
+	if len(workbook.Sheets) == 0 {
+		return fallback
+	}
+
+	for _, rel := range rels.Relationships {
+		if rel.ID == workbook.Sheets[0].RID {
+			if strings.HasPrefix(rel.Target, "/") {
+				return strings.TrimPrefix(rel.Target, "/")
+			}
+			return path.Join("xl", rel.Target)
+		}
+	}
+	return fallback
+}
+
+// decodeZipXML decodifica una parte XML del XLSX, sin descomprimir más de
+// maxXLSXPartSize bytes (evita que un zip bomb se infle completo en memoria).
+func decodeZipXML(f *zip.File, v interface{}) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	limited := &io.LimitedReader{R: rc, N: maxXLSXPartSize + 1}
+	err = xml.NewDecoder(limited).Decode(v)
+	if limited.N <= 0 {
+		return fmt.Errorf("invalid XLSX file (%s): content too large (max %d MB)", f.Name, maxXLSXPartSize>>20)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid XLSX file (%s): %w", f.Name, err)
+	}
+	return nil
+}
+
+// columnIndex convierte una referencia de celda ("C12") en índice de columna base 0.
+// Falla si no empieza con letras o si supera la última columna de Excel (XFD).
+func columnIndex(ref string) (int, error) {
+	idx := 0
+	for _, ch := range strings.ToUpper(ref) {
+		if ch < 'A' || ch > 'Z' {
+			break
+		}
+		idx = idx*26 + int(ch-'A'+1)
+		if idx > maxXLSXColumns {
+			return 0, fmt.Errorf("invalid XLSX cell reference: %s", ref)
+		}
+	}
+	if idx == 0 {
+		return 0, fmt.Errorf("invalid XLSX cell reference: %s", ref)
+	}
+	return idx - 1, nil
+}
+
+// ExcelSerialToDate convierte un número de serie de fecha de Excel (ej: "36526") a YYYY-MM-DD.
+func ExcelSerialToDate(serial string) (string, bool) {
+	days, err := strconv.ParseFloat(serial, 64)
+	if err != nil || days <= 0 {
+		return "", false
+	}
+	base := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+	return base.AddDate(0, 0, int(days)).Format("2006-01-02"), true
+}
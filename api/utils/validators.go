@@ -1,8 +1,11 @@
 package utils
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
+
+	"github.com/google/uuid"
 )
 
 func ValidateRUT(rut string) bool {
@@ -51,3 +54,27 @@ func calculateVerifier(rutBody int) string {
 	}
 	return strconv.Itoa(mod)
 }
+
+// ParseUUIDs convierte una lista de strings en UUIDs, fallando con el primero inválido.
+func ParseUUIDs(values []string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(values))
+	for _, v := range values {
+		id, err := uuid.Parse(strings.TrimSpace(v))
+		if err != nil {
+			return nil, fmt.Errorf("invalid ID: %s", v)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// NormalizeRUT deja el RUT sin puntos, guion ni espacios y en mayúsculas (ej: "12345678K"),
+// para comparar RUTs escritos en distintos formatos.
+func NormalizeRUT(rut string) string {
+	rut = strings.TrimSpace(rut)
+	rut = strings.ToUpper(rut)
+	rut = strings.ReplaceAll(rut, ".", "")
+	rut = strings.ReplaceAll(rut, "-", "")
+	rut = strings.ReplaceAll(rut, " ", "")
+	return rut
+}
@@ -6,19 +6,29 @@ import (
 	"bitacora-medica-backend/api/config"
 	"bitacora-medica-backend/api/database"
 	"bitacora-medica-backend/api/handlers/admin"
+	"bitacora-medica-backend/api/handlers/appointments"
 	"bitacora-medica-backend/api/handlers/auth"
+	"bitacora-medica-backend/api/handlers/calendar"
 	"bitacora-medica-backend/api/handlers/collaborations"
+	"bitacora-medica-backend/api/handlers/comments"
 	"bitacora-medica-backend/api/handlers/common"
+	"bitacora-medica-backend/api/handlers/diagnoses"
+	"bitacora-medica-backend/api/handlers/incidents"
+	"bitacora-medica-backend/api/handlers/mentions"
+	"bitacora-medica-backend/api/handlers/organizations"
 	"bitacora-medica-backend/api/handlers/patients"
 	"bitacora-medica-backend/api/handlers/professional"
 	"bitacora-medica-backend/api/handlers/reports"
 	"bitacora-medica-backend/api/handlers/sessions"
 	"bitacora-medica-backend/api/handlers/support"
+	"bitacora-medica-backend/api/handlers/tags"
+	"bitacora-medica-backend/api/handlers/templates"
 	"time"
 
 	"github.com/gin-contrib/cors"
 
 	"bitacora-medica-backend/api/middleware"
+	"bitacora-medica-backend/api/services"
 	_ "bitacora-medica-backend/docs"
 
 	"github.com/gin-gonic/gin"
@@ -50,6 +60,15 @@ func main() {
 	cfg := config.LoadConfig()
 
 	database.Connect(cfg.DBUrl)
+	database.Migrate()
+
+	// El catálogo CIE-10 se carga al iniciar para fallar de inmediato si falta en producción
+	if _, err := services.GetDiagnosisCatalog(cfg); err != nil {
+		slog.Error("Failed to load CIE-10 catalog", "error", err)
+		panic("Failed to load CIE-10 catalog")
+	}
+
+	services.StartIncidentEscalation(cfg)
 
 	r := gin.Default()
 
@@ -70,6 +89,9 @@ func main() {
 		c.JSON(200, gin.H{"message": "pong"})
 	})
 
+	// Feed iCalendar público: se autentica con el token secreto de la URL
+	r.GET("/calendar/feed/:token", calendar.CalendarFeedHandler())
+
 	api := r.Group("/api")
 
 	api.Use(middleware.RateLimitMiddleware())
@@ -88,17 +110,91 @@ func main() {
 
 			patientsGroup.GET("/", patients.ListPatientsHandler())
 
+			patientsGroup.POST("/import", patients.ImportPatientsHandler())
+
 			patientsGroup.GET("/:id", patients.GetPatientProfileHandler(cfg))
 
 			patientsGroup.PUT("/:id", patients.UpdatePatientHandler())
 
 			patientsGroup.GET("/:id/ai-context", patients.GetPatientAIContextHandler())
 
+			patientsGroup.GET("/:id/timeline", patients.GetPatientTimelineHandler())
+
+			patientsGroup.POST("/:id/discharge", patients.DischargePatientHandler())
+
+			patientsGroup.POST("/:id/reactivate", patients.ReactivatePatientHandler())
+
+			patientsGroup.PUT("/:id/status", patients.ChangePatientStatusHandler())
+
+			patientsGroup.GET("/:id/status-history", patients.GetPatientStatusHistoryHandler())
+
+			patientsGroup.GET("/:id/diagnoses", patients.ListDiagnosesHandler())
+
+			patientsGroup.POST("/:id/diagnoses", patients.AddDiagnosisHandler(cfg))
+
+			patientsGroup.PUT("/:id/diagnoses/:diagnosis_id", patients.UpdateDiagnosisHandler())
+
+			patientsGroup.DELETE("/:id/diagnoses/:diagnosis_id", patients.DeleteDiagnosisHandler())
+
+			patientsGroup.GET("/:id/medications", patients.ListMedicationsHandler())
+
+			patientsGroup.POST("/:id/medications", patients.AddMedicationHandler())
+
+			patientsGroup.PUT("/:id/medications/:med_id", patients.UpdateMedicationHandler())
+
+			patientsGroup.POST("/:id/medications/:med_id/stop", patients.StopMedicationHandler())
+
+			patientsGroup.GET("/:id/alerts", patients.ListAlertsHandler())
+
+			patientsGroup.POST("/:id/alerts", patients.CreateAlertHandler())
+
+			patientsGroup.PUT("/:id/alerts/:alert_id", patients.UpdateAlertHandler())
+
+			patientsGroup.GET("/:id/goals", patients.ListGoalsHandler())
+
+			patientsGroup.POST("/:id/goals", patients.CreateGoalHandler())
+
+			patientsGroup.PUT("/:id/goals/:goal_id", patients.UpdateGoalHandler())
+
+			patientsGroup.GET("/:id/goals/:goal_id/progress", patients.GetGoalProgressHandler())
+
+			patientsGroup.POST("/:id/goals/:goal_id/progress", patients.RecordGoalProgressHandler())
+
+			patientsGroup.GET("/:id/contacts", patients.ListContactsHandler())
+
+			patientsGroup.POST("/:id/contacts", patients.CreateContactHandler())
+
+			patientsGroup.PUT("/:id/contacts/:contact_id", patients.UpdateContactHandler())
+
+			patientsGroup.DELETE("/:id/contacts/:contact_id", patients.DeleteContactHandler())
+
+			patientsGroup.GET("/:id/tags", patients.ListPatientTagsHandler())
+
+			patientsGroup.POST("/:id/tags", patients.AddPatientTagHandler())
+
+			patientsGroup.DELETE("/:id/tags/:tag_id", patients.RemovePatientTagHandler())
+
 			patientsGroup.POST("/:id/documents", patients.UploadDocumentHandler(cfg))
 
 			patientsGroup.GET("/:id/documents", patients.ListDocumentsHandler(cfg))
 
 			patientsGroup.DELETE("/:id/documents/:doc_id", patients.DeleteDocumentHandler())
+
+			patientsGroup.GET("/:id/attendance", patients.ListAttendanceHandler())
+
+			patientsGroup.POST("/:id/attendance", patients.CreateAttendanceHandler())
+
+			patientsGroup.GET("/:id/vitals/trend", patients.GetVitalsTrendHandler())
+
+			patientsGroup.GET("/:id/vital-thresholds", patients.ListVitalThresholdsHandler())
+
+			patientsGroup.PUT("/:id/vital-thresholds", patients.SetVitalThresholdHandler())
+
+			patientsGroup.DELETE("/:id/vital-thresholds/:vital_key", patients.DeleteVitalThresholdHandler())
+
+			patientsGroup.GET("/:id/comments", comments.ListPatientCommentsHandler())
+
+			patientsGroup.POST("/:id/comments", comments.CreatePatientCommentHandler(cfg))
 		}
 
 		// --- GRUPO DE SESIONES ---
@@ -109,11 +205,157 @@ func main() {
 
 			sessionsGroup.GET("/", sessions.ListSessionsHandler(cfg))
 
+			sessionsGroup.GET("/drafts", sessions.ListDraftsHandler())
+
+			sessionsGroup.POST("/drafts", sessions.CreateDraftHandler())
+
+			sessionsGroup.GET("/drafts/:draft_id", sessions.GetDraftHandler())
+
+			sessionsGroup.PUT("/drafts/:draft_id", sessions.SaveDraftHandler())
+
+			sessionsGroup.DELETE("/drafts/:draft_id", sessions.DeleteDraftHandler())
+
+			sessionsGroup.POST("/drafts/:draft_id/submit", sessions.SubmitDraftHandler(cfg))
+
 			sessionsGroup.GET("/:id", sessions.GetSessionHandler(cfg))
 
-			sessionsGroup.PUT("/:id", sessions.UpdateSessionHandler())
+			sessionsGroup.PUT("/:id", sessions.UpdateSessionHandler(cfg))
 
 			sessionsGroup.DELETE("/:id", sessions.DeleteSessionHandler())
+
+			sessionsGroup.POST("/:id/cosign/approve", sessions.ApproveSessionHandler())
+
+			sessionsGroup.POST("/:id/cosign/return", sessions.ReturnSessionHandler(cfg))
+
+			sessionsGroup.GET("/:id/comments", comments.ListSessionCommentsHandler())
+
+			sessionsGroup.POST("/:id/comments", comments.CreateSessionCommentHandler(cfg))
+		}
+
+		// --- GRUPO DE COMENTARIOS ---
+		commentsGroup := api.Group("/comments")
+		{
+			commentsGroup.PUT("/:id", comments.UpdateCommentHandler(cfg))
+
+			commentsGroup.DELETE("/:id", comments.DeleteCommentHandler())
+
+			commentsGroup.GET("/:id/history", comments.GetCommentHistoryHandler())
+		}
+
+		// --- GRUPO DE MENCIONES ---
+		mentionsGroup := api.Group("/mentions")
+		{
+			mentionsGroup.GET("/", mentions.ListMentionsHandler())
+
+			mentionsGroup.POST("/:id/resolve", mentions.ResolveMentionHandler())
+		}
+
+		// --- GRUPO DE SUPERVISIÓN ---
+		api.GET("/supervision/queue", sessions.ListCosignQueueHandler())
+
+		// --- GRUPO DE SESIONES GRUPALES ---
+		groupSessionsGroup := api.Group("/group-sessions")
+		{
+			groupSessionsGroup.GET("/", sessions.ListGroupSessionsHandler())
+
+			groupSessionsGroup.POST("/", sessions.CreateGroupSessionHandler(cfg))
+
+			groupSessionsGroup.GET("/:id", sessions.GetGroupSessionHandler())
+
+			groupSessionsGroup.PUT("/:id", sessions.UpdateGroupSessionHandler(cfg))
+		}
+
+		// --- GRUPO DE CITAS ---
+		appointmentsGroup := api.Group("/appointments")
+		{
+			appointmentsGroup.GET("/", appointments.ListAppointmentsHandler())
+
+			appointmentsGroup.POST("/", appointments.CreateAppointmentHandler())
+
+			appointmentsGroup.GET("/:id", appointments.GetAppointmentHandler())
+
+			appointmentsGroup.PUT("/:id", appointments.UpdateAppointmentHandler())
+
+			appointmentsGroup.PUT("/:id/status", appointments.ChangeAppointmentStatusHandler())
+
+			appointmentsGroup.GET("/:id/session-input", appointments.GetAppointmentSessionInputHandler())
+
+			appointmentsGroup.GET("/:id/ics", appointments.DownloadAppointmentICSHandler())
+		}
+
+		// --- GRUPO DE INCIDENTES ---
+		incidentsGroup := api.Group("/incidents")
+		{
+			incidentsGroup.GET("/", incidents.ListIncidentsHandler())
+
+			incidentsGroup.GET("/:id", incidents.GetIncidentHandler())
+
+			incidentsGroup.PUT("/:id", incidents.UpdateIncidentHandler())
+
+			incidentsGroup.POST("/:id/acknowledge", incidents.AcknowledgeIncidentHandler())
+
+			incidentsGroup.PUT("/:id/status", incidents.ChangeIncidentStatusHandler())
+
+			incidentsGroup.POST("/:id/actions", incidents.CreateIncidentActionHandler())
+
+			incidentsGroup.PUT("/:id/actions/:action_id", incidents.UpdateIncidentActionHandler())
+		}
+
+		// --- GRUPO DE CALENDARIO (FEED iCAL) ---
+		calendarGroup := api.Group("/calendar")
+		{
+			calendarGroup.GET("/token", calendar.GetCalendarTokenHandler())
+
+			calendarGroup.POST("/token", calendar.RegenerateCalendarTokenHandler(cfg))
+
+			calendarGroup.DELETE("/token", calendar.RevokeCalendarTokenHandler())
+		}
+
+		// --- GRUPO DE SIGNOS VITALES ---
+		vitalsGroup := api.Group("/vitals")
+		{
+			vitalsGroup.GET("/catalog", sessions.GetVitalsCatalogHandler())
+		}
+
+		// --- GRUPO DE DIAGNÓSTICOS (CIE-10) ---
+		diagnosesGroup := api.Group("/diagnoses")
+		{
+			diagnosesGroup.GET("/search", diagnoses.SearchDiagnosesHandler(cfg))
+		}
+
+		// --- GRUPO DE ETIQUETAS Y COHORTES ---
+		tagsGroup := api.Group("/tags")
+		{
+			tagsGroup.GET("/", tags.ListTagsHandler())
+
+			tagsGroup.POST("/", tags.CreateTagHandler())
+
+			tagsGroup.DELETE("/:id", tags.DeleteTagHandler())
+		}
+
+		cohortsGroup := api.Group("/cohorts")
+		{
+			cohortsGroup.GET("/", tags.ListCohortsHandler())
+
+			cohortsGroup.POST("/", tags.CreateCohortHandler())
+
+			cohortsGroup.DELETE("/:id", tags.DeleteCohortHandler())
+		}
+
+		api.GET("/organizations", organizations.ListMyOrganizationsHandler())
+
+		// --- GRUPO DE PLANTILLAS DE SESIÓN ---
+		templatesGroup := api.Group("/session-templates")
+		{
+			templatesGroup.GET("/", templates.ListTemplatesHandler())
+
+			templatesGroup.POST("/", templates.CreateTemplateHandler())
+
+			templatesGroup.GET("/:id", templates.GetTemplateHandler())
+
+			templatesGroup.PUT("/:id", templates.UpdateTemplateHandler())
+
+			templatesGroup.DELETE("/:id", templates.DeleteTemplateHandler())
 		}
 
 		// --- GRUPO DE SUBIDAS ---
@@ -122,6 +364,11 @@ func main() {
 		uploads.POST("/image", common.UploadImageHandler(cfg))
 
 		uploads.POST("/consent", common.UploadConsentHandler(cfg))
+
+		uploads.POST("/document", common.UploadDocumentFileHandler(cfg))
+
+		// --- SINCRONIZACIÓN OFFLINE ---
+		api.POST("/sync", sessions.SyncBatchHandler(cfg))
 	}
 
 	// --- GRUPO DE COLABORACIONES ---
@@ -173,6 +420,22 @@ func main() {
 		adminGroup.PUT("/users/:id/review", admin.ReviewUserHandler(cfg))
 
 		adminGroup.GET("/dashboard", admin.GetDashboardStatsHandler())
+
+		adminGroup.GET("/organizations", admin.ListOrganizationsHandler())
+
+		adminGroup.POST("/organizations", admin.CreateOrganizationHandler())
+
+		adminGroup.GET("/organizations/:id/members", admin.ListOrganizationMembersHandler())
+
+		adminGroup.POST("/organizations/:id/members", admin.AddOrganizationMemberHandler())
+
+		adminGroup.DELETE("/organizations/:id/members/:user_id", admin.RemoveOrganizationMemberHandler())
+
+		adminGroup.GET("/supervisions", admin.ListSupervisionsHandler())
+
+		adminGroup.POST("/supervisions", admin.CreateSupervisionHandler())
+
+		adminGroup.DELETE("/supervisions/:id", admin.DeleteSupervisionHandler())
 	}
 
 	slog.Info("Server starting on port " + cfg.Port)